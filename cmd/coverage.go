@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"github.com/erNail/labdoc/internal/config"
+	"github.com/erNail/labdoc/internal/gitlab"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// NewCoverageCmd creates a new command for reporting the documentation coverage
+// of GitLab CI/CD components, i.e. the percentage of components with a description,
+// inputs with a description and jobs with a comment.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - coverageReporter: An interface for reporting the documentation coverage.
+//
+// Returns:
+//   - *cobra.Command: A pointer to the newly created cobra.Command.
+func NewCoverageCmd(filesystem afero.Fs, coverageReporter gitlab.CoverageReporter) *cobra.Command {
+	var (
+		componentDir   string
+		configFilePath string
+		minCoverage    float64
+		badgeFilePath  string
+	)
+
+	coverageCmd := &cobra.Command{
+		Use:   "coverage",
+		Short: "Report the documentation coverage of GitLab CI/CD components",
+		Long:  `Report the percentage of documented components, inputs and jobs for each component`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			coverageReporter.ReportCoverage(
+				filesystem,
+				cmd.OutOrStdout(),
+				componentDir,
+				configFilePath,
+				minCoverage,
+				badgeFilePath,
+			)
+		},
+	}
+
+	coverageCmd.Flags().StringVarP(
+		&componentDir, "componentDir", "d", "templates",
+		"The directory containing the GitLab CI/CD components",
+	)
+	coverageCmd.Flags().StringVar(
+		&configFilePath, "config", config.DefaultConfigFilePath,
+		"The labdoc configuration file. If it does not exist, the defaults are used",
+	)
+	coverageCmd.Flags().Float64Var(
+		&minCoverage, "min-coverage", 0,
+		"The minimum coverage in percent. If the coverage is lower, the application exits with a non-zero exit code",
+	)
+	coverageCmd.Flags().StringVar(
+		&badgeFilePath, "badge", "",
+		"If set, an SVG badge showing the coverage is written to this path",
+	)
+
+	return coverageCmd
+}
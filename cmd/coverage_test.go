@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCoverageReporter struct {
+	mock.Mock
+}
+
+func (m *MockCoverageReporter) ReportCoverage(
+	filesystem afero.Fs,
+	writer io.Writer,
+	componentDirectory string,
+	configFilePath string,
+	minCoverage float64,
+	badgeFilePath string,
+) {
+	m.Called(filesystem, writer, componentDirectory, configFilePath, minCoverage, badgeFilePath)
+}
+
+func TestCoverageCmdUsesDefaults(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockCoverageReporter := new(MockCoverageReporter)
+	mockCoverageReporter.On(
+		"ReportCoverage", filesystem, mock.Anything, "templates", ".labdoc.yml", 0.0, "",
+	).Return()
+
+	cmd := NewCoverageCmd(filesystem, mockCoverageReporter)
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockCoverageReporter.AssertExpectations(t)
+}
+
+func TestCoverageCmdPassesMinCoverageAndBadge(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockCoverageReporter := new(MockCoverageReporter)
+	mockCoverageReporter.On(
+		"ReportCoverage", filesystem, mock.Anything, "templates", ".labdoc.yml", 80.5, "coverage.svg",
+	).Return()
+
+	cmd := NewCoverageCmd(filesystem, mockCoverageReporter)
+	cmd.SetArgs([]string{"--min-coverage=80.5", "--badge=coverage.svg"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockCoverageReporter.AssertExpectations(t)
+}
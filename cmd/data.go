@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"github.com/erNail/labdoc/internal/config"
+	"github.com/erNail/labdoc/internal/gitlab"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// NewDataCmd creates a new command for printing the data that is passed to
+// the documentation template. It processes YAML files from a specified
+// directory and prints the result as JSON or YAML.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - dataExporter: An interface for exporting the documentation data.
+//
+// Returns:
+//   - *cobra.Command: A pointer to the newly created cobra.Command.
+func NewDataCmd(filesystem afero.Fs, dataExporter gitlab.DocumentationDataExporter) *cobra.Command {
+	var (
+		repoURL          string
+		componentVersion string
+		componentDir     string
+		format           string
+		configFilePath   string
+		includeHidden    bool
+	)
+
+	dataCmd := &cobra.Command{
+		Use:   "data",
+		Short: "Print the data passed to documentation templates",
+		Long:  `Print the data that is passed to documentation templates, built from a directory of CI/CD components`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			dataExporter.ExportDocumentationData(
+				filesystem,
+				cmd.OutOrStdout(),
+				componentDir,
+				repoURL,
+				componentVersion,
+				format,
+				configFilePath,
+				includeHidden,
+			)
+		},
+	}
+
+	repoURLFlag := "repoUrl"
+
+	dataCmd.Flags().StringVarP(
+		&repoURL, repoURLFlag, "r", "",
+		"The repository URL from which to include the GitLab CI/CD Component (required)",
+	)
+	dataCmd.Flags().StringVarP(
+		&componentVersion, "version", "v", "latest",
+		"The current version or ref of the GitLab CI/CD Component",
+	)
+	dataCmd.Flags().StringVarP(
+		&componentDir, "componentDir", "d", "templates",
+		"The directory containing the GitLab CI/CD components",
+	)
+	dataCmd.Flags().StringVarP(
+		&format, "format", "f", "json",
+		"The format of the printed data. Either json or yaml",
+	)
+	dataCmd.Flags().StringVar(
+		&configFilePath, "config", config.DefaultConfigFilePath,
+		"The labdoc configuration file. If it does not exist, the defaults are used",
+	)
+
+	dataCmd.Flags().BoolVar(
+		&includeHidden, "includeHidden", false,
+		"If set, hidden components, inputs and jobs and components of all statuses are included, "+
+			"e.g. to create a baseline for the lint command",
+	)
+
+	err := dataCmd.MarkFlagRequired(repoURLFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return dataCmd
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockDocumentationDataExporter struct {
+	mock.Mock
+}
+
+func (m *MockDocumentationDataExporter) ExportDocumentationData(
+	filesystem afero.Fs,
+	writer io.Writer,
+	componentDirectory string,
+	repoURL string,
+	componentVersion string,
+	format string,
+	configFilePath string,
+	includeHidden bool,
+) {
+	m.Called(filesystem, writer, componentDirectory, repoURL, componentVersion, format, configFilePath, includeHidden)
+}
+
+func TestDataCmdThrowsErrorIfRepoURLIsNotSet(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewDataCmd(afero.NewMemMapFs(), new(MockDocumentationDataExporter))
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `required flag(s) "repoUrl" not set`)
+}
+
+func TestDataCmdIsSuccessfulWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockDataExporter := new(MockDocumentationDataExporter)
+	mockDataExporter.On(
+		"ExportDocumentationData",
+		filesystem,
+		mock.Anything,
+		"templates",
+		"github.com/test",
+		"latest",
+		"json",
+		".labdoc.yml",
+		false,
+	).Return()
+
+	cmd := NewDataCmd(filesystem, mockDataExporter)
+	cmd.SetArgs([]string{"--repoUrl=github.com/test"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockDataExporter.AssertExpectations(t)
+}
+
+func TestDataCmdPassesFormatAndIncludeHidden(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockDataExporter := new(MockDocumentationDataExporter)
+	mockDataExporter.On(
+		"ExportDocumentationData",
+		filesystem,
+		mock.Anything,
+		"templates",
+		"github.com/test",
+		"latest",
+		"yaml",
+		".labdoc.yml",
+		true,
+	).Return()
+
+	cmd := NewDataCmd(filesystem, mockDataExporter)
+	cmd.SetArgs([]string{"--repoUrl=github.com/test", "--format=yaml", "--includeHidden"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockDataExporter.AssertExpectations(t)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/erNail/labdoc/internal/config"
 	"github.com/erNail/labdoc/internal/gitlab"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
@@ -25,6 +26,9 @@ func NewGenerateCmd(filesystem afero.Fs, documentationGenerator gitlab.Documenta
 		templateFilePath string
 		outputFilePath   string
 		checkOnly        bool
+		strict           bool
+		configFilePath   string
+		statuses         []string
 	)
 
 	generateCmd := &cobra.Command{
@@ -40,6 +44,9 @@ func NewGenerateCmd(filesystem afero.Fs, documentationGenerator gitlab.Documenta
 				componentVersion,
 				outputFilePath,
 				checkOnly,
+				strict,
+				configFilePath,
+				statuses,
 			)
 		},
 	}
@@ -70,6 +77,21 @@ func NewGenerateCmd(filesystem afero.Fs, documentationGenerator gitlab.Documenta
 		&checkOnly, "check", "c", false,
 		"If set, will check if the documentation is up-to-date. If not, the application will exit with exit code 2",
 	)
+	generateCmd.Flags().BoolVarP(
+		&strict, "strict", "s", false,
+		"If set, the template is checked for unknown fields, missing map keys cause an error "+
+			"and invalid input defaults, e.g. defaults that are not one of the options, cause an error",
+	)
+	generateCmd.Flags().StringVar(
+		&configFilePath, "config", config.DefaultConfigFilePath,
+		"The labdoc configuration file. If it does not exist, the defaults are used",
+	)
+
+	generateCmd.Flags().StringSliceVar(
+		&statuses, "status", []string{},
+		"Only document components with one of these statuses, e.g. \"stable,beta\". "+
+			"Components without a status are stable",
+	)
 
 	err := generateCmd.MarkFlagRequired(repoURLFlag)
 	if err != nil {
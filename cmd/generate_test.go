@@ -20,8 +20,22 @@ func (m *MockDocumentationGenerator) GenerateDocumentation(filesystem afero.Fs,
 	componentVersion string,
 	outputFilePath string,
 	checkOnly bool,
+	strict bool,
+	configFilePath string,
+	componentStatuses []string,
 ) {
-	m.Called(filesystem, componentDirectory, templateFilePath, repoURL, componentVersion, outputFilePath, checkOnly)
+	m.Called(
+		filesystem,
+		componentDirectory,
+		templateFilePath,
+		repoURL,
+		componentVersion,
+		outputFilePath,
+		checkOnly,
+		strict,
+		configFilePath,
+		componentStatuses,
+	)
 }
 
 func TestGenerateCmdThrowsErrorIfRepoUrlIsNotSet(t *testing.T) {
@@ -50,6 +64,9 @@ func TestGenerateCmdIsSuccessfulWithRequiredParametersSet(t *testing.T) {
 		"latest",
 		"templates/README.md",
 		false,
+		false,
+		".labdoc.yml",
+		[]string{},
 	).Return()
 
 	cmd := NewGenerateCmd(filesystem, mockDocumentationGenerator)
@@ -60,3 +77,59 @@ func TestGenerateCmdIsSuccessfulWithRequiredParametersSet(t *testing.T) {
 	require.NoError(t, err)
 	mockDocumentationGenerator.AssertExpectations(t)
 }
+
+func TestGenerateCmdPassesStrictFlag(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockDocumentationGenerator := new(MockDocumentationGenerator)
+	mockDocumentationGenerator.On(
+		"GenerateDocumentation",
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"github.com/test",
+		"latest",
+		"templates/README.md",
+		false,
+		true,
+		".labdoc.yml",
+		[]string{},
+	).Return()
+
+	cmd := NewGenerateCmd(filesystem, mockDocumentationGenerator)
+	cmd.SetArgs([]string{"--repoUrl=github.com/test", "--strict"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockDocumentationGenerator.AssertExpectations(t)
+}
+
+func TestGenerateCmdPassesStatusFlag(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockDocumentationGenerator := new(MockDocumentationGenerator)
+	mockDocumentationGenerator.On(
+		"GenerateDocumentation",
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"github.com/test",
+		"latest",
+		"templates/README.md",
+		false,
+		false,
+		".labdoc.yml",
+		[]string{"stable", "beta"},
+	).Return()
+
+	cmd := NewGenerateCmd(filesystem, mockDocumentationGenerator)
+	cmd.SetArgs([]string{"--repoUrl=github.com/test", "--status=stable,beta"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockDocumentationGenerator.AssertExpectations(t)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/erNail/labdoc/internal/gitlab"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// NewImagesCmd creates a new command for printing the container images that
+// GitLab CI/CD components use via the `image` and `services` keywords.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - imageInventoryExporter: An interface for exporting the image inventory.
+//
+// Returns:
+//   - *cobra.Command: A pointer to the newly created cobra.Command.
+func NewImagesCmd(filesystem afero.Fs, imageInventoryExporter gitlab.ImageInventoryExporter) *cobra.Command {
+	var (
+		componentDir string
+		format       string
+	)
+
+	imagesCmd := &cobra.Command{
+		Use:   "images",
+		Short: "Print the container images used by GitLab CI/CD components",
+		Long:  `Print the container images of the image and services keywords of all GitLab CI/CD components`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			imageInventoryExporter.ExportImageInventory(filesystem, cmd.OutOrStdout(), componentDir, format)
+		},
+	}
+
+	imagesCmd.Flags().StringVarP(
+		&componentDir, "componentDir", "d", "templates",
+		"The directory containing the GitLab CI/CD components",
+	)
+	imagesCmd.Flags().StringVarP(
+		&format, "format", "f", "json",
+		"The format of the printed inventory. Either json or cyclonedx",
+	)
+
+	return imagesCmd
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockImageInventoryExporter struct {
+	mock.Mock
+}
+
+func (m *MockImageInventoryExporter) ExportImageInventory(
+	filesystem afero.Fs,
+	writer io.Writer,
+	componentDirectory string,
+	format string,
+) {
+	m.Called(filesystem, writer, componentDirectory, format)
+}
+
+func TestImagesCmdUsesDefaults(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockImageInventoryExporter := new(MockImageInventoryExporter)
+	mockImageInventoryExporter.On("ExportImageInventory", filesystem, mock.Anything, "templates", "json").Return()
+
+	cmd := NewImagesCmd(filesystem, mockImageInventoryExporter)
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockImageInventoryExporter.AssertExpectations(t)
+}
+
+func TestImagesCmdPassesFlags(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockImageInventoryExporter := new(MockImageInventoryExporter)
+	mockImageInventoryExporter.On("ExportImageInventory", filesystem, mock.Anything, "components", "cyclonedx").Return()
+
+	cmd := NewImagesCmd(filesystem, mockImageInventoryExporter)
+	cmd.SetArgs([]string{"--componentDir", "components", "--format", "cyclonedx"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockImageInventoryExporter.AssertExpectations(t)
+}
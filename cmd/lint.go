@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/erNail/labdoc/internal/gitlab"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// NewLintCmd creates a new command for linting GitLab CI/CD components.
+// It checks the components against rules like the removal of stable inputs.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - linter: An interface for linting components.
+//
+// Returns:
+//   - *cobra.Command: A pointer to the newly created cobra.Command.
+func NewLintCmd(filesystem afero.Fs, linter gitlab.Linter) *cobra.Command {
+	var (
+		componentDir     string
+		baselineFilePath string
+	)
+
+	lintCmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Lint GitLab CI/CD components",
+		Long:  `Check GitLab CI/CD components for issues. Exits with a non-zero exit code if issues are found`,
+		Run: func(_ *cobra.Command, _ []string) {
+			linter.Lint(filesystem, componentDir, baselineFilePath)
+		},
+	}
+
+	lintCmd.Flags().StringVarP(
+		&componentDir, "componentDir", "d", "templates",
+		"The directory containing the GitLab CI/CD components",
+	)
+	lintCmd.Flags().StringVarP(
+		&baselineFilePath, "baseline", "b", "",
+		"The documentation data of a previous version, as exported by the data command. "+
+			"Required for rules that compare versions",
+	)
+
+	return lintCmd
+}
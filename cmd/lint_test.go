@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockLinter struct {
+	mock.Mock
+}
+
+func (m *MockLinter) Lint(filesystem afero.Fs, componentDirectory string, baselineFilePath string) {
+	m.Called(filesystem, componentDirectory, baselineFilePath)
+}
+
+func TestLintCmdUsesDefaults(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockLinter := new(MockLinter)
+	mockLinter.On("Lint", filesystem, "templates", "").Return()
+
+	cmd := NewLintCmd(filesystem, mockLinter)
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockLinter.AssertExpectations(t)
+}
+
+func TestLintCmdPassesBaseline(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockLinter := new(MockLinter)
+	mockLinter.On("Lint", filesystem, "components", "baseline.json").Return()
+
+	cmd := NewLintCmd(filesystem, mockLinter)
+	cmd.SetArgs([]string{"--componentDir=components", "--baseline=baseline.json"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockLinter.AssertExpectations(t)
+}
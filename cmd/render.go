@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"github.com/erNail/labdoc/internal/gitlab"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// NewRenderCmd creates a new command for rendering a documentation template
+// against a data fixture, as printed by the data command. No component
+// directory is needed.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - templateRenderer: An interface for rendering templates.
+//
+// Returns:
+//   - *cobra.Command: A pointer to the newly created cobra.Command.
+func NewRenderCmd(filesystem afero.Fs, templateRenderer gitlab.TemplateRenderer) *cobra.Command {
+	var (
+		dataFilePath     string
+		templateFilePath string
+		outputFilePath   string
+		strict           bool
+	)
+
+	renderCmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render a documentation template from a data fixture",
+		Long:  `Render a documentation template from a JSON or YAML data fixture, without any CI/CD components`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			templateRenderer.RenderTemplate(
+				filesystem,
+				cmd.OutOrStdout(),
+				dataFilePath,
+				templateFilePath,
+				outputFilePath,
+				strict,
+			)
+		},
+	}
+
+	dataFlag := "data"
+
+	renderCmd.Flags().StringVar(
+		&dataFilePath, dataFlag, "",
+		"The JSON or YAML file containing the documentation data (required)",
+	)
+	renderCmd.Flags().StringVarP(
+		&templateFilePath, "template", "t", "resources/default-template.md.gotmpl",
+		"The template file from which the documentation is rendered",
+	)
+	renderCmd.Flags().StringVarP(
+		&outputFilePath, "outputFile", "o", "",
+		"The path and name of the rendered file to be created. If not set, the result is printed",
+	)
+	renderCmd.Flags().BoolVarP(
+		&strict, "strict", "s", false,
+		"If set, the template is checked for unknown fields and missing map keys cause an error",
+	)
+
+	err := renderCmd.MarkFlagRequired(dataFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return renderCmd
+}
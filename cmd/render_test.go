@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTemplateRenderer struct {
+	mock.Mock
+}
+
+func (m *MockTemplateRenderer) RenderTemplate(
+	filesystem afero.Fs,
+	writer io.Writer,
+	dataFilePath string,
+	templateFilePath string,
+	outputFilePath string,
+	strict bool,
+) {
+	m.Called(filesystem, writer, dataFilePath, templateFilePath, outputFilePath, strict)
+}
+
+func TestRenderCmdThrowsErrorIfDataIsNotSet(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRenderCmd(afero.NewMemMapFs(), new(MockTemplateRenderer))
+	cmd.SetArgs([]string{"--template=t.gotmpl"})
+
+	err := cmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `required flag(s) "data" not set`)
+}
+
+func TestRenderCmdIsSuccessfulWithRequiredParametersSet(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockTemplateRenderer := new(MockTemplateRenderer)
+	mockTemplateRenderer.On(
+		"RenderTemplate",
+		filesystem,
+		mock.Anything,
+		"fixture.json",
+		"t.gotmpl",
+		"",
+		false,
+	).Return()
+
+	cmd := NewRenderCmd(filesystem, mockTemplateRenderer)
+	cmd.SetArgs([]string{"--data=fixture.json", "--template=t.gotmpl"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockTemplateRenderer.AssertExpectations(t)
+}
@@ -25,7 +25,21 @@ func NewRootCmd() *cobra.Command {
 
 	filesystem := afero.NewOsFs()
 	documentationGenerator := &gitlab.RealDocumentationGenerator{}
+	templateChecker := &gitlab.RealTemplateChecker{}
+	dataExporter := &gitlab.RealDocumentationDataExporter{}
+	templateRenderer := &gitlab.RealTemplateRenderer{}
+	templateTester := &gitlab.RealTemplateTester{}
+	linter := &gitlab.RealLinter{}
+	coverageReporter := &gitlab.RealCoverageReporter{}
+	imageInventoryExporter := &gitlab.RealImageInventoryExporter{}
 	rootCmd.AddCommand(NewGenerateCmd(filesystem, documentationGenerator))
+	rootCmd.AddCommand(NewTemplateCmd(filesystem, templateChecker))
+	rootCmd.AddCommand(NewDataCmd(filesystem, dataExporter))
+	rootCmd.AddCommand(NewRenderCmd(filesystem, templateRenderer))
+	rootCmd.AddCommand(NewTestCmd(filesystem, templateTester))
+	rootCmd.AddCommand(NewLintCmd(filesystem, linter))
+	rootCmd.AddCommand(NewCoverageCmd(filesystem, coverageReporter))
+	rootCmd.AddCommand(NewImagesCmd(filesystem, imageInventoryExporter))
 
 	return rootCmd
 }
@@ -38,3 +38,80 @@ func TestRootCmdCallsGenerateSubcommand(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestRootCmdCallsTemplateSubcommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"template", "check", "-h"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+}
+
+func TestRootCmdCallsDataSubcommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"data", "-h"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+}
+
+func TestRootCmdCallsRenderSubcommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"render", "-h"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+}
+
+func TestRootCmdCallsTestSubcommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"test", "-h"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+}
+
+func TestRootCmdCallsLintSubcommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"lint", "-h"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+}
+
+func TestRootCmdCallsCoverageSubcommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"coverage", "-h"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+}
+
+func TestRootCmdCallsImagesSubcommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"images", "-h"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+}
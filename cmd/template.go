@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"github.com/erNail/labdoc/internal/gitlab"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// NewTemplateCmd creates a new command that groups the subcommands for
+// working with documentation templates.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - templateChecker: An interface for checking templates.
+//
+// Returns:
+//   - *cobra.Command: A pointer to the newly created cobra.Command.
+func NewTemplateCmd(filesystem afero.Fs, templateChecker gitlab.TemplateChecker) *cobra.Command {
+	templateCmd := &cobra.Command{
+		Use:   "template",
+		Short: "Work with documentation templates",
+		Long:  "Work with the Go templates that are used to render the documentation",
+	}
+
+	templateCmd.AddCommand(NewTemplateCheckCmd(filesystem, templateChecker))
+
+	return templateCmd
+}
+
+// NewTemplateCheckCmd creates a new command for statically checking a
+// documentation template. It reports every field that does not exist on
+// the data passed to the template, together with its line number.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - templateChecker: An interface for checking templates.
+//
+// Returns:
+//   - *cobra.Command: A pointer to the newly created cobra.Command.
+func NewTemplateCheckCmd(filesystem afero.Fs, templateChecker gitlab.TemplateChecker) *cobra.Command {
+	var templateFilePath string
+
+	templateCheckCmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check a documentation template for unknown fields",
+		Long:  "Check a documentation template for fields that do not exist on the documentation data",
+		Run: func(_ *cobra.Command, _ []string) {
+			templateChecker.CheckTemplate(filesystem, templateFilePath)
+		},
+	}
+
+	templateCheckCmd.Flags().StringVarP(
+		&templateFilePath, "template", "t", "resources/default-template.md.gotmpl",
+		"The template file to check",
+	)
+
+	return templateCheckCmd
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTemplateChecker struct {
+	mock.Mock
+}
+
+func (m *MockTemplateChecker) CheckTemplate(filesystem afero.Fs, templateFilePath string) {
+	m.Called(filesystem, templateFilePath)
+}
+
+func TestTemplateCheckCmdUsesDefaultTemplate(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockTemplateChecker := new(MockTemplateChecker)
+	mockTemplateChecker.On("CheckTemplate", filesystem, "resources/default-template.md.gotmpl").Return()
+
+	cmd := NewTemplateCmd(filesystem, mockTemplateChecker)
+	cmd.SetArgs([]string{"check"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockTemplateChecker.AssertExpectations(t)
+}
+
+func TestTemplateCheckCmdUsesCustomTemplate(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockTemplateChecker := new(MockTemplateChecker)
+	mockTemplateChecker.On("CheckTemplate", filesystem, "my-template.md.gotmpl").Return()
+
+	cmd := NewTemplateCmd(filesystem, mockTemplateChecker)
+	cmd.SetArgs([]string{"check", "--template", "my-template.md.gotmpl"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockTemplateChecker.AssertExpectations(t)
+}
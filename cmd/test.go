@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"github.com/erNail/labdoc/internal/gitlab"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// NewTestCmd creates a new command for testing a documentation template
+// against golden files. Each test case is a directory containing a
+// "components" directory with component fixtures and an "expected.md" file.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - templateTester: An interface for testing templates.
+//
+// Returns:
+//   - *cobra.Command: A pointer to the newly created cobra.Command.
+func NewTestCmd(filesystem afero.Fs, templateTester gitlab.TemplateTester) *cobra.Command {
+	var (
+		testDir          string
+		templateFilePath string
+		repoURL          string
+		componentVersion string
+		update           bool
+	)
+
+	testCmd := &cobra.Command{
+		Use:   "test",
+		Short: "Test a documentation template against golden files",
+		Long:  `Render the component fixtures of each test case and compare the result with the expected file`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			templateTester.TestTemplate(
+				filesystem,
+				cmd.OutOrStdout(),
+				testDir,
+				templateFilePath,
+				repoURL,
+				componentVersion,
+				update,
+			)
+		},
+	}
+
+	testCmd.Flags().StringVarP(
+		&testDir, "testDir", "d", "labdoc-tests",
+		"The directory containing the test cases",
+	)
+	testCmd.Flags().StringVarP(
+		&templateFilePath, "template", "t", "resources/default-template.md.gotmpl",
+		"The template file under test",
+	)
+	testCmd.Flags().StringVarP(
+		&repoURL, "repoUrl", "r", "gitlab.com/example/components",
+		"The repository URL used when rendering the test cases",
+	)
+	testCmd.Flags().StringVarP(
+		&componentVersion, "version", "v", "1.0.0",
+		"The version used when rendering the test cases",
+	)
+	testCmd.Flags().BoolVarP(
+		&update, "update", "u", false,
+		"If set, the expected files are overwritten with the rendered documentation",
+	)
+
+	return testCmd
+}
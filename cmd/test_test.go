@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTemplateTester struct {
+	mock.Mock
+}
+
+func (m *MockTemplateTester) TestTemplate(
+	filesystem afero.Fs,
+	writer io.Writer,
+	testDirectory string,
+	templateFilePath string,
+	repoURL string,
+	componentVersion string,
+	update bool,
+) {
+	m.Called(filesystem, writer, testDirectory, templateFilePath, repoURL, componentVersion, update)
+}
+
+func TestTestCmdIsSuccessfulWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockTemplateTester := new(MockTemplateTester)
+	mockTemplateTester.On(
+		"TestTemplate",
+		filesystem,
+		mock.Anything,
+		"labdoc-tests",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/example/components",
+		"1.0.0",
+		false,
+	).Return()
+
+	cmd := NewTestCmd(filesystem, mockTemplateTester)
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockTemplateTester.AssertExpectations(t)
+}
+
+func TestTestCmdPassesUpdateFlag(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	mockTemplateTester := new(MockTemplateTester)
+	mockTemplateTester.On(
+		"TestTemplate",
+		filesystem,
+		mock.Anything,
+		"tests",
+		"t.gotmpl",
+		"gitlab.com/example/components",
+		"1.0.0",
+		true,
+	).Return()
+
+	cmd := NewTestCmd(filesystem, mockTemplateTester)
+	cmd.SetArgs([]string{"--testDir=tests", "--template=t.gotmpl", "--update"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	mockTemplateTester.AssertExpectations(t)
+}
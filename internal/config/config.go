@@ -0,0 +1,257 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigFilePath is the path of the configuration file that is used if no other path is given.
+const DefaultConfigFilePath = ".labdoc.yml"
+
+const (
+	// ReferenceStylePinned includes the component with the version that is being documented.
+	ReferenceStylePinned = "pinned"
+	// ReferenceStyleLatest includes the latest released version of the component.
+	ReferenceStyleLatest = "latest"
+	// ReferenceStyleMajor includes the latest release of the major version that is being documented.
+	ReferenceStyleMajor = "major"
+	// ReferenceStyleSha includes the component with a commit SHA.
+	ReferenceStyleSha = "sha"
+)
+
+const (
+	// CommentPolicyFallback uses the comment of an input as description if the input has no description.
+	CommentPolicyFallback = "fallback"
+	// CommentPolicyAppend appends the comment of an input to its description.
+	CommentPolicyAppend = "append"
+	// CommentPolicyIgnore never uses the comment of an input as description.
+	CommentPolicyIgnore = "ignore"
+)
+
+// defaultScriptMaxLines is the number of lines after which scripts are truncated by default.
+const defaultScriptMaxLines = 30
+
+// referenceStyleTitles are the default titles of the reference styles.
+var referenceStyleTitles = map[string]string{
+	ReferenceStylePinned: "Pinned version",
+	ReferenceStyleLatest: "Latest release",
+	ReferenceStyleMajor:  "Latest release of the major version",
+	ReferenceStyleSha:    "Commit SHA",
+}
+
+// Config represents the labdoc configuration file.
+type Config struct {
+	Usage   UsageConfig   `yaml:"usage"`
+	Inputs  InputsConfig  `yaml:"inputs"`
+	Hidden  HiddenConfig  `yaml:"hidden"`
+	Scripts ScriptsConfig `yaml:"scripts"`
+	// Categories group the components in the documentation, in the order in which they are rendered.
+	Categories []CategoryConfig `yaml:"categories"`
+	// Statuses restricts the documentation to components with one of the statuses. Empty means all.
+	Statuses []string `yaml:"statuses"`
+}
+
+// UsageConfig configures the usage examples of the components.
+type UsageConfig struct {
+	References []ReferenceConfig `yaml:"references"`
+}
+
+// InputsConfig configures the documentation of the inputs of the components.
+type InputsConfig struct {
+	// CommentPolicy is one of "fallback", "append" or "ignore". Defaults to "fallback".
+	CommentPolicy string `yaml:"commentPolicy"`
+}
+
+// ScriptsConfig configures the script snippets of the jobs in the documentation.
+type ScriptsConfig struct {
+	// Show renders the scripts of all jobs. Jobs can override it via the `@script` annotation.
+	Show bool `yaml:"show"`
+	// MaxLines is the number of lines after which a script is truncated. Defaults to 30.
+	MaxLines int `yaml:"maxLines"`
+}
+
+// HiddenConfig configures which elements are excluded from the documentation.
+// All values are glob patterns matching the names of the elements, e.g. "test-*".
+type HiddenConfig struct {
+	Components []string `yaml:"components"`
+	Inputs     []string `yaml:"inputs"`
+	Jobs       []string `yaml:"jobs"`
+}
+
+// CategoryConfig assigns components to a category.
+type CategoryConfig struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Components are glob patterns matching the names of the components in the category.
+	Components []string `yaml:"components"`
+}
+
+// ReferenceConfig configures one variant of the reference used in the `include:component` keyword.
+type ReferenceConfig struct {
+	// Title is shown above the usage example. Defaults to a title matching the style.
+	Title string `yaml:"title"`
+	// Style is one of "pinned", "latest", "major" or "sha". Defaults to "pinned".
+	Style string `yaml:"style"`
+	// Ref is the commit SHA used by the "sha" style, e.g. "$CI_COMMIT_SHA". Variables are kept as written.
+	Ref string `yaml:"ref"`
+	// Host replaces the host of the repository URL, e.g. "$CI_SERVER_FQDN" for self-managed mirrors.
+	Host string `yaml:"host"`
+}
+
+// NewDefaultConfig creates the configuration that is used if no configuration file exists.
+//
+// Returns:
+//   - Config: The default configuration.
+func NewDefaultConfig() Config {
+	return Config{
+		Usage: UsageConfig{
+			References: []ReferenceConfig{
+				{Style: ReferenceStylePinned, Title: referenceStyleTitles[ReferenceStylePinned]},
+			},
+		},
+		Inputs: InputsConfig{
+			CommentPolicy: CommentPolicyFallback,
+		},
+		Scripts: ScriptsConfig{
+			MaxLines: defaultScriptMaxLines,
+		},
+	}
+}
+
+// LoadConfig reads the configuration file from the given path. If the file does not
+// exist, the default configuration is returned. Missing values are set to their defaults.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - configFilePath: The path to the configuration file.
+//
+// Returns:
+//   - Config: The loaded configuration.
+//   - error: An error if the configuration file can not be read or is invalid.
+func LoadConfig(filesystem afero.Fs, configFilePath string) (Config, error) {
+	configFileContent, err := afero.ReadFile(filesystem, configFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		log.WithField("filePath", configFilePath).Debug("No configuration file found. Using defaults")
+
+		return NewDefaultConfig(), nil
+	}
+
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	log.WithField("filePath", configFilePath).Info("Using configuration file")
+
+	var config Config
+
+	decoder := yaml.NewDecoder(bytes.NewReader(configFileContent))
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(&config)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("failed to parse configuration file: %w", err)
+	}
+
+	err = config.setDefaults()
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid configuration file: %w", err)
+	}
+
+	return config, nil
+}
+
+// setDefaults sets all missing values of the configuration to their defaults and
+// validates the values that are set.
+//
+// Returns:
+//   - error: An error if a value is invalid.
+func (config *Config) setDefaults() error {
+	if len(config.Usage.References) == 0 {
+		config.Usage.References = NewDefaultConfig().Usage.References
+	}
+
+	for index := range config.Usage.References {
+		reference := &config.Usage.References[index]
+
+		if reference.Style == "" {
+			reference.Style = ReferenceStylePinned
+		}
+
+		switch reference.Style {
+		case ReferenceStylePinned, ReferenceStyleLatest, ReferenceStyleMajor:
+		case ReferenceStyleSha:
+			if reference.Ref == "" {
+				return fmt.Errorf("usage reference %d uses style %q but sets no ref", index, reference.Style)
+			}
+		default:
+			return fmt.Errorf("usage reference %d has unknown style %q", index, reference.Style)
+		}
+
+		if reference.Title == "" {
+			reference.Title = referenceStyleTitles[reference.Style]
+		}
+	}
+
+	switch config.Inputs.CommentPolicy {
+	case "":
+		config.Inputs.CommentPolicy = CommentPolicyFallback
+	case CommentPolicyFallback, CommentPolicyAppend, CommentPolicyIgnore:
+	default:
+		return fmt.Errorf("inputs has unknown comment policy %q", config.Inputs.CommentPolicy)
+	}
+
+	switch {
+	case config.Scripts.MaxLines == 0:
+		config.Scripts.MaxLines = defaultScriptMaxLines
+	case config.Scripts.MaxLines < 0:
+		return fmt.Errorf("scripts has negative max lines %d", config.Scripts.MaxLines)
+	}
+
+	for _, pattern := range slices.Concat(config.Hidden.Components, config.Hidden.Inputs, config.Hidden.Jobs) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("hidden pattern %q is invalid: %w", pattern, err)
+		}
+	}
+
+	for index, category := range config.Categories {
+		if category.Name == "" {
+			return fmt.Errorf("category %d sets no name", index)
+		}
+
+		for _, pattern := range category.Components {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("component pattern %q of category %q is invalid: %w", pattern, category.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MatchesAny checks if a name matches at least one of the given glob patterns.
+//
+// Parameters:
+//   - patterns: The glob patterns, e.g. "test-*".
+//   - name: The name to match.
+//
+// Returns:
+//   - bool: True if the name matches at least one pattern.
+func MatchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		// The patterns are validated when loading the configuration.
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
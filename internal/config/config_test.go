@@ -0,0 +1,130 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigReturnsDefaultsIfFileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	config, err := LoadConfig(afero.NewMemMapFs(), DefaultConfigFilePath)
+
+	require.NoError(t, err)
+	assert.Equal(t, NewDefaultConfig(), config)
+}
+
+func TestLoadConfigReturnsDefaultsForEmptyFile(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, DefaultConfigFilePath, []byte(""), 0o644)
+	require.NoError(t, err)
+
+	config, err := LoadConfig(filesystem, DefaultConfigFilePath)
+
+	require.NoError(t, err)
+	assert.Equal(t, NewDefaultConfig(), config)
+}
+
+func TestLoadConfigReadsUsageReferences(t *testing.T) {
+	t.Parallel()
+
+	configContent := `---
+usage:
+  references:
+    - style: "pinned"
+    - style: "latest"
+      title: "Always up-to-date"
+    - style: "sha"
+      ref: "abc123"
+      host: "$CI_SERVER_FQDN"
+...
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, DefaultConfigFilePath, []byte(configContent), 0o644)
+	require.NoError(t, err)
+
+	expectedReferences := []ReferenceConfig{
+		{Style: ReferenceStylePinned, Title: "Pinned version"},
+		{Style: ReferenceStyleLatest, Title: "Always up-to-date"},
+		{Style: ReferenceStyleSha, Title: "Commit SHA", Ref: "abc123", Host: "$CI_SERVER_FQDN"},
+	}
+
+	config, err := LoadConfig(filesystem, DefaultConfigFilePath)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedReferences, config.Usage.References)
+}
+
+func TestLoadConfigReturnsErrorOnUnknownField(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, DefaultConfigFilePath, []byte("usgae: {}\n"), 0o644)
+	require.NoError(t, err)
+
+	_, err = LoadConfig(filesystem, DefaultConfigFilePath)
+
+	require.Error(t, err)
+}
+
+func TestLoadConfigReturnsErrorOnInvalidReference(t *testing.T) {
+	t.Parallel()
+
+	for _, configContent := range []string{
+		"usage: {references: [{style: \"nightly\"}]}\n",
+		"usage: {references: [{style: \"sha\"}]}\n",
+		"inputs: {commentPolicy: \"replace\"}\n",
+		"hidden: {jobs: [\"[\"]}\n",
+		"categories: [{components: [\"build-*\"]}]\n",
+		"categories: [{name: \"Build\", components: [\"[\"]}]\n",
+		"scripts: {maxLines: -1}\n",
+	} {
+		filesystem := afero.NewMemMapFs()
+		err := afero.WriteFile(filesystem, DefaultConfigFilePath, []byte(configContent), 0o644)
+		require.NoError(t, err)
+
+		_, err = LoadConfig(filesystem, DefaultConfigFilePath)
+
+		require.Error(t, err)
+	}
+}
+
+func TestLoadConfigReadsInputsCommentPolicy(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, DefaultConfigFilePath, []byte("inputs: {commentPolicy: \"append\"}\n"), 0o644)
+	require.NoError(t, err)
+
+	config, err := LoadConfig(filesystem, DefaultConfigFilePath)
+
+	require.NoError(t, err)
+	assert.Equal(t, CommentPolicyAppend, config.Inputs.CommentPolicy)
+}
+
+func TestLoadConfigReadsScripts(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, DefaultConfigFilePath, []byte("scripts: {show: true, maxLines: 10}\n"), 0o644)
+	require.NoError(t, err)
+
+	config, err := LoadConfig(filesystem, DefaultConfigFilePath)
+
+	require.NoError(t, err)
+	assert.Equal(t, ScriptsConfig{Show: true, MaxLines: 10}, config.Scripts)
+}
+
+func TestMatchesAnyMatchesGlobPatterns(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, MatchesAny([]string{"other", "test-*"}, "test-input"))
+	assert.False(t, MatchesAny([]string{"test-*"}, "input"))
+	assert.False(t, MatchesAny(nil, "input"))
+}
@@ -0,0 +1,285 @@
+package gitlab
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// undocumentedMarker excludes the commented element from the documentation.
+const undocumentedMarker = "labdoc:hidden"
+
+const (
+	// StatusExperimental marks elements that may change or be removed at any time.
+	StatusExperimental = "experimental"
+	// StatusBeta marks elements that are feature complete, but may still change.
+	StatusBeta = "beta"
+	// StatusStable marks elements that only change in a backwards compatible way.
+	StatusStable = "stable"
+	// StatusDeprecated marks elements that will be removed.
+	StatusDeprecated = "deprecated"
+)
+
+// statuses are all supported values of the `@status` annotation.
+var statuses = []string{StatusExperimental, StatusBeta, StatusStable, StatusDeprecated}
+
+const (
+	// ScriptShow renders the scripts of a job, regardless of the configuration.
+	ScriptShow = "show"
+	// ScriptHide omits the scripts of a job, regardless of the configuration.
+	ScriptHide = "hide"
+)
+
+// scriptVisibilities are all supported values of the `@script` annotation.
+var scriptVisibilities = []string{ScriptShow, ScriptHide}
+
+// Annotations are the structured annotations of a doc comment, like `@deprecated` or `@since`.
+type Annotations struct {
+	// Deprecated is true if the comment contains `@deprecated`.
+	Deprecated bool
+	// DeprecationReason is the text following `@deprecated`.
+	DeprecationReason string
+	// Since is the version following `@since`.
+	Since string
+	// Examples are the texts of all `@example` annotations. Indented lines below an
+	// `@example` are part of the example.
+	Examples []string
+	// Internal is true if the comment contains `@internal`. Internal elements are excluded from the documentation.
+	Internal bool
+	// Group is the name following `@group`.
+	Group string
+	// See are the references following all `@see` annotations.
+	See []string
+	// Undocumented is true if the comment contains the `labdoc:hidden` marker.
+	Undocumented bool
+	// Status is the maturity following `@status`, e.g. "stable".
+	// Elements with `@deprecated` but without `@status` have the status "deprecated".
+	Status string
+	// ValidValues are the values following all `@valid` annotations. The `regex` of an input must match them.
+	ValidValues []string
+	// InvalidValues are the values following all `@invalid` annotations. The `regex` of an input must not
+	// match them.
+	InvalidValues []string
+	// Script is the visibility of the job scripts following `@script`, either "show" or "hide".
+	// Empty if the configuration decides.
+	Script string
+}
+
+// IsHidden checks if the element is excluded from the documentation via `labdoc:hidden` or `@internal`.
+//
+// Returns:
+//   - bool: True if the element is hidden.
+func (annotations Annotations) IsHidden() bool {
+	return annotations.Undocumented || annotations.Internal
+}
+
+// TableExamples returns the examples formatted for a single Markdown table cell. Single-line examples
+// are inline code, the lines of multi-line examples are joined with `<br>` so that they do not break the row.
+//
+// Returns:
+//   - []string: The formatted examples, e.g. "`value`" or "<code>first<br>second</code>".
+func (annotations Annotations) TableExamples() []string {
+	var examples []string
+
+	for _, example := range annotations.Examples {
+		if strings.Contains(example, "\n") {
+			examples = append(examples, "<code>"+strings.ReplaceAll(example, "\n", "<br>")+"</code>")
+		} else {
+			examples = append(examples, "`"+example+"`")
+		}
+	}
+
+	return examples
+}
+
+// parseAnnotations extracts the annotations from a plain text comment. Lines starting with
+// a known annotation and the `labdoc:hidden` marker are removed from the comment.
+// Unknown annotations are kept as text.
+//
+// Parameters:
+//   - comment: The plain text comment.
+//
+// Returns:
+//   - string: The comment without annotation lines.
+//   - Annotations: The parsed annotations.
+func parseAnnotations(comment string) (string, Annotations) {
+	text, annotations := parseExplicitAnnotations(comment)
+
+	return text, withDefaultStatus(annotations)
+}
+
+// parseExplicitAnnotations extracts the annotations from a plain text comment like parseAnnotations,
+// but does not set the status of deprecated elements without a `@status`.
+//
+// Parameters:
+//   - comment: The plain text comment.
+//
+// Returns:
+//   - string: The comment without annotation lines.
+//   - Annotations: The annotations as written in the comment.
+func parseExplicitAnnotations(comment string) (string, Annotations) {
+	annotations := Annotations{}
+	textLines := []string{}
+	inExample := false
+
+	for _, line := range strings.Split(comment, "\n") {
+		isIndented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if inExample && isIndented {
+			lastIndex := len(annotations.Examples) - 1
+			annotations.Examples[lastIndex] = strings.TrimPrefix(
+				annotations.Examples[lastIndex]+"\n"+strings.TrimPrefix(line, "  "),
+				"\n",
+			)
+
+			continue
+		}
+
+		inExample = false
+
+		if strings.TrimSpace(line) == undocumentedMarker {
+			annotations.Undocumented = true
+
+			continue
+		}
+
+		keyword, value, isAnnotation := cutAnnotation(line)
+		if !isAnnotation {
+			textLines = append(textLines, line)
+
+			continue
+		}
+
+		switch keyword {
+		case "deprecated":
+			annotations.Deprecated = true
+			annotations.DeprecationReason = value
+		case "since":
+			annotations.Since = value
+		case "example":
+			annotations.Examples = append(annotations.Examples, value)
+			inExample = true
+		case "internal":
+			annotations.Internal = true
+		case "group":
+			annotations.Group = value
+		case "see":
+			annotations.See = append(annotations.See, value)
+		case "valid":
+			annotations.ValidValues = append(annotations.ValidValues, unquoteAnnotationValue(value))
+		case "invalid":
+			annotations.InvalidValues = append(annotations.InvalidValues, unquoteAnnotationValue(value))
+		case "status":
+			if slices.Contains(statuses, value) {
+				annotations.Status = value
+			} else {
+				log.WithFields(log.Fields{"status": value, "knownStatuses": statuses}).Warn("Ignoring unknown status")
+			}
+		case "script":
+			if slices.Contains(scriptVisibilities, value) {
+				annotations.Script = value
+			} else {
+				log.WithFields(log.Fields{"script": value, "knownValues": scriptVisibilities}).
+					Warn("Ignoring unknown script visibility")
+			}
+		}
+	}
+
+	return strings.Trim(strings.Join(textLines, "\n"), "\n"), annotations
+}
+
+// withDefaultStatus sets the status of deprecated annotations without a `@status` to "deprecated".
+//
+// Parameters:
+//   - annotations: The annotations as written in the comment.
+//
+// Returns:
+//   - Annotations: The annotations with the default status.
+func withDefaultStatus(annotations Annotations) Annotations {
+	if annotations.Deprecated && annotations.Status == "" {
+		annotations.Status = StatusDeprecated
+	}
+
+	return annotations
+}
+
+// mergeAnnotations merges two sets of annotations. Values of the primary annotations take precedence,
+// while examples and references of both are combined. An explicit `@status` of either annotations takes
+// precedence over the status of deprecated elements, so the annotations are expected as parsed by
+// parseExplicitAnnotations.
+//
+// Parameters:
+//   - primary: The annotations whose values take precedence, as written in the comment.
+//   - secondary: The annotations used for values the primary annotations do not set, as written in the comment.
+//
+// Returns:
+//   - Annotations: The merged annotations.
+func mergeAnnotations(primary Annotations, secondary Annotations) Annotations {
+	merged := primary
+	merged.Deprecated = primary.Deprecated || secondary.Deprecated
+	merged.Internal = primary.Internal || secondary.Internal
+	merged.Undocumented = primary.Undocumented || secondary.Undocumented
+	merged.Examples = slices.Concat(primary.Examples, secondary.Examples)
+	merged.See = slices.Concat(primary.See, secondary.See)
+	merged.ValidValues = slices.Concat(primary.ValidValues, secondary.ValidValues)
+	merged.InvalidValues = slices.Concat(primary.InvalidValues, secondary.InvalidValues)
+
+	for _, field := range []struct {
+		target *string
+		value  string
+	}{
+		{&merged.DeprecationReason, secondary.DeprecationReason},
+		{&merged.Since, secondary.Since},
+		{&merged.Group, secondary.Group},
+		{&merged.Status, secondary.Status},
+		{&merged.Script, secondary.Script},
+	} {
+		if *field.target == "" {
+			*field.target = field.value
+		}
+	}
+
+	return withDefaultStatus(merged)
+}
+
+// cutAnnotation splits a comment line into the keyword and the value of an annotation.
+//
+// Parameters:
+//   - line: The comment line, e.g. "@since 1.4.0".
+//
+// Returns:
+//   - string: The keyword of the annotation, e.g. "since".
+//   - string: The value of the annotation, e.g. "1.4.0".
+//   - bool: True if the line starts with a known annotation.
+func cutAnnotation(line string) (string, string, bool) {
+	if !strings.HasPrefix(line, "@") {
+		return "", "", false
+	}
+
+	keyword, value, _ := strings.Cut(strings.TrimPrefix(line, "@"), " ")
+
+	switch keyword {
+	case "deprecated", "since", "example", "internal", "group", "see", "status", "valid", "invalid", "script":
+		return keyword, strings.TrimSpace(value), true
+	default:
+		return "", "", false
+	}
+}
+
+// unquoteAnnotationValue removes the double quotes around an annotation value. Quotes allow
+// values with leading or trailing whitespace and the empty value.
+//
+// Parameters:
+//   - value: The annotation value, e.g. "\"v1.0\"".
+//
+// Returns:
+//   - string: The unquoted value, or the value itself if it is not quoted.
+func unquoteAnnotationValue(value string) string {
+	unquotedValue, err := strconv.Unquote(value)
+	if err != nil || !strings.HasPrefix(value, "\"") {
+		return value
+	}
+
+	return unquotedValue
+}
@@ -0,0 +1,118 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAnnotationsExtractsAllAnnotations(t *testing.T) {
+	t.Parallel()
+
+	comment := `Builds the project.
+@deprecated Use the build-v2 component instead.
+@since 1.4.0
+@internal
+@group Build
+@see https://docs.gitlab.com/ee/ci/
+More details.`
+
+	expectedAnnotations := Annotations{
+		Deprecated:        true,
+		DeprecationReason: "Use the build-v2 component instead.",
+		Since:             "1.4.0",
+		Internal:          true,
+		Group:             "Build",
+		See:               []string{"https://docs.gitlab.com/ee/ci/"},
+		Status:            StatusDeprecated,
+	}
+
+	text, annotations := parseAnnotations(comment)
+	assert.Equal(t, "Builds the project.\nMore details.", text)
+	assert.Equal(t, expectedAnnotations, annotations)
+}
+
+func TestParseAnnotationsReadsIndentedExampleLines(t *testing.T) {
+	t.Parallel()
+
+	comment := `Description
+@example
+  include:
+    - component: "my-component"
+@example "single line"`
+
+	text, annotations := parseAnnotations(comment)
+	assert.Equal(t, "Description", text)
+	assert.Equal(t, []string{"include:\n  - component: \"my-component\"", "\"single line\""}, annotations.Examples)
+}
+
+func TestParseAnnotationsKeepsUnknownAnnotations(t *testing.T) {
+	t.Parallel()
+
+	text, annotations := parseAnnotations("Contact @maintainers\n@todo Improve")
+	assert.Equal(t, "Contact @maintainers\n@todo Improve", text)
+	assert.Equal(t, Annotations{}, annotations)
+}
+
+func TestParseAnnotationsRecognizesHiddenMarker(t *testing.T) {
+	t.Parallel()
+
+	text, annotations := parseAnnotations("Only used in tests.\nlabdoc:hidden")
+	assert.Equal(t, "Only used in tests.", text)
+	assert.True(t, annotations.Undocumented)
+}
+
+func TestParseAnnotationsReadsStatus(t *testing.T) {
+	t.Parallel()
+
+	_, annotations := parseAnnotations("@status beta")
+	assert.Equal(t, StatusBeta, annotations.Status)
+
+	_, annotations = parseAnnotations("@status unknown")
+	assert.Empty(t, annotations.Status)
+
+	_, annotations = parseAnnotations("@deprecated\n@status stable")
+	assert.Equal(t, StatusStable, annotations.Status)
+}
+
+func TestMergeAnnotationsPrefersExplicitStatusOverDeprecation(t *testing.T) {
+	t.Parallel()
+
+	_, primary := parseExplicitAnnotations("@deprecated Use another input.")
+	_, secondary := parseExplicitAnnotations("@status beta")
+	assert.Equal(t, StatusBeta, mergeAnnotations(primary, secondary).Status)
+
+	_, secondary = parseExplicitAnnotations("The input")
+	assert.Equal(t, StatusDeprecated, mergeAnnotations(primary, secondary).Status)
+	assert.Equal(t, StatusDeprecated, mergeAnnotations(secondary, primary).Status)
+}
+
+func TestParseAnnotationsReadsScriptVisibility(t *testing.T) {
+	t.Parallel()
+
+	_, annotations := parseAnnotations("@script show")
+	assert.Equal(t, ScriptShow, annotations.Script)
+
+	_, annotations = parseAnnotations("@script hide")
+	assert.Equal(t, ScriptHide, annotations.Script)
+
+	_, annotations = parseAnnotations("@script sometimes")
+	assert.Empty(t, annotations.Script)
+}
+
+func TestParseAnnotationsReadsValidAndInvalidValues(t *testing.T) {
+	t.Parallel()
+
+	text, annotations := parseAnnotations("The version\n@valid v1.0\n@invalid \"\"\n@invalid 1.0")
+	assert.Equal(t, "The version", text)
+	assert.Equal(t, []string{"v1.0"}, annotations.ValidValues)
+	assert.Equal(t, []string{"", "1.0"}, annotations.InvalidValues)
+}
+
+func TestTableExamplesJoinsLinesOfMultiLineExamples(t *testing.T) {
+	t.Parallel()
+
+	annotations := Annotations{Examples: []string{"value", "first: 1\nsecond: 2"}}
+
+	assert.Equal(t, []string{"`value`", "<code>first: 1<br>second: 2</code>"}, annotations.TableExamples())
+}
@@ -0,0 +1,174 @@
+package gitlab
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dotenvReportType is the type of reports that pass variables to downstream jobs.
+const dotenvReportType = "dotenv"
+
+// dotenvAssignmentPattern matches script lines that write or append a variable to a file,
+// like `echo "VERSION=1.0.0" >> build.env`. The groups are the variable name and the file.
+var dotenvAssignmentPattern = regexp.MustCompile(
+	`^\s*(?:echo|printf)\s+(?:-\w+\s+)?["']?([A-Za-z_][A-Za-z0-9_]*)=.*>>?\s*["']?([^"'\s]+)["']?\s*$`,
+)
+
+// Artifacts are the artifacts of a job, as set via the `artifacts` keyword.
+type Artifacts struct {
+	// Paths are the paths of `artifacts:paths`.
+	Paths []string
+	// ExposeAs is the name of `artifacts:expose_as`, under which the artifacts are shown in merge requests.
+	ExposeAs string
+	// ExpireIn is the duration of `artifacts:expire_in`, e.g. "1 week".
+	ExpireIn string
+	// Reports are the reports of `artifacts:reports`, in the order of their definition.
+	Reports []ArtifactReport
+	// DotenvVariables are the variables the scripts of the job write into a dotenv report.
+	// They are available to downstream jobs.
+	DotenvVariables []string
+}
+
+// ArtifactReport is a report of `artifacts:reports`.
+type ArtifactReport struct {
+	// Type is the type of the report, e.g. "junit" or "dotenv".
+	Type string
+	// Paths are the files of the report.
+	Paths []string
+}
+
+// parseArtifacts parses the `artifacts` keyword of a job.
+//
+// Parameters:
+//   - jobNode: The mapping node of the effective job configuration.
+//
+// Returns:
+//   - *Artifacts: The artifacts of the job. Nil if the job has no artifacts.
+func parseArtifacts(jobNode *yaml.Node) *Artifacts {
+	artifactsNode := mappingValue(jobNode, "artifacts")
+	if artifactsNode == nil || artifactsNode.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	artifacts := Artifacts{Paths: parsePaths(mappingValue(artifactsNode, "paths"))}
+
+	if exposeAsNode := mappingValue(artifactsNode, "expose_as"); exposeAsNode != nil {
+		artifacts.ExposeAs = exposeAsNode.Value
+	}
+
+	if expireInNode := mappingValue(artifactsNode, "expire_in"); expireInNode != nil {
+		artifacts.ExpireIn = expireInNode.Value
+	}
+
+	reportsNode := mappingValue(artifactsNode, "reports")
+	if reportsNode != nil && reportsNode.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(reportsNode.Content); i += 2 {
+			reportNode := reportsNode.Content[i+1]
+
+			// Reports like `coverage_report` are mappings with a single `path`.
+			if reportNode.Kind == yaml.MappingNode {
+				reportNode = mappingValue(reportNode, "path")
+			}
+
+			artifacts.Reports = append(artifacts.Reports, ArtifactReport{
+				Type:  reportsNode.Content[i].Value,
+				Paths: parsePaths(reportNode),
+			})
+		}
+	}
+
+	artifacts.DotenvVariables = findDotenvVariables(jobNode, artifacts.Reports)
+
+	return &artifacts
+}
+
+// findDotenvVariables finds the variables that the scripts of a job write to one of its dotenv reports.
+//
+// Parameters:
+//   - jobNode: The mapping node of the effective job configuration.
+//   - reports: The reports of the job.
+//
+// Returns:
+//   - []string: The names of the variables, in order of their first assignment.
+func findDotenvVariables(jobNode *yaml.Node, reports []ArtifactReport) []string {
+	var variables []string
+
+	dotenvPaths := []string{}
+
+	for _, report := range reports {
+		if report.Type == dotenvReportType {
+			dotenvPaths = append(dotenvPaths, report.Paths...)
+		}
+	}
+
+	if len(dotenvPaths) == 0 {
+		return variables
+	}
+
+	for _, keyword := range []string{"before_script", "script", "after_script"} {
+		for _, scriptLine := range parsePaths(flattenSequence(mappingValue(jobNode, keyword))) {
+			for _, line := range strings.Split(scriptLine, "\n") {
+				match := dotenvAssignmentPattern.FindStringSubmatch(line)
+				if match == nil || slices.Contains(variables, match[1]) {
+					continue
+				}
+
+				if slices.Contains(dotenvPaths, path.Clean(match[2])) || slices.Contains(dotenvPaths, match[2]) {
+					variables = append(variables, match[1])
+				}
+			}
+		}
+	}
+
+	return variables
+}
+
+// flattenSequence flattens nested sequences, like scripts that use `!reference`.
+// `!reference` tags that could not be resolved are kept as a literal scalar, like `!reference [.setup, script]`,
+// instead of being read as commands.
+//
+// Parameters:
+//   - node: The node to flatten. Nil nodes stay nil.
+//
+// Returns:
+//   - *yaml.Node: A sequence of the scalars of the node, or the node itself if it is not a sequence.
+func flattenSequence(node *yaml.Node) *yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return node
+	}
+
+	if isReferenceNode(node) {
+		return &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{formatReferenceNode(node)}}
+	}
+
+	flattenedNode := &yaml.Node{Kind: yaml.SequenceNode}
+
+	for _, item := range node.Content {
+		if item.Kind == yaml.SequenceNode {
+			flattenedNode.Content = append(flattenedNode.Content, flattenSequence(item).Content...)
+		} else {
+			flattenedNode.Content = append(flattenedNode.Content, item)
+		}
+	}
+
+	return flattenedNode
+}
+
+// formatReferenceNode formats a `!reference` tag as a scalar.
+//
+// Parameters:
+//   - referenceNode: The sequence node tagged with `!reference`.
+//
+// Returns:
+//   - *yaml.Node: A scalar node with the tag as written, e.g. "!reference [.setup, script]".
+func formatReferenceNode(referenceNode *yaml.Node) *yaml.Node {
+	return &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Value: referenceTag + " [" + strings.Join(referencePath(referenceNode), ", ") + "]",
+		Line:  referenceNode.Line,
+	}
+}
@@ -0,0 +1,88 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalYAMLParsesArtifactsAndReports(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `.scripts:
+  version:
+    - echo "VERSION=1.0.0" >> build.env
+build:
+  script:
+    - !reference [.scripts, version]
+    - echo "IGNORED=1" >> other.env
+    - |
+      make
+      echo 'IMAGE="$CI_REGISTRY_IMAGE"' >> ./build.env
+  after_script:
+    - echo "VERSION=2.0.0" >> build.env
+  artifacts:
+    paths: ["dist/"]
+    expose_as: "Build output"
+    expire_in: "1 week"
+    reports:
+      dotenv: "build.env"
+      junit: ["report.xml", "other.xml"]
+      coverage_report:
+        coverage_format: "cobertura"
+        path: "coverage.xml"
+test:
+  script: "echo test"
+`
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Artifacts{
+		Paths:    []string{"dist/"},
+		ExposeAs: "Build output",
+		ExpireIn: "1 week",
+		Reports: []ArtifactReport{
+			{Type: "dotenv", Paths: []string{"build.env"}},
+			{Type: "junit", Paths: []string{"report.xml", "other.xml"}},
+			{Type: "coverage_report", Paths: []string{"coverage.xml"}},
+		},
+		DotenvVariables: []string{"VERSION", "IMAGE"},
+	}, gitlabCiConfig.Jobs[0].Artifacts)
+	assert.Nil(t, gitlabCiConfig.Jobs[1].Artifacts)
+}
+
+func TestFindDotenvVariablesRequiresDotenvReport(t *testing.T) {
+	t.Parallel()
+
+	var jobNode yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("script:\n  - echo \"A=1\" >> build.env\n"), &jobNode))
+
+	assert.Empty(t, findDotenvVariables(
+		jobNode.Content[0],
+		[]ArtifactReport{{Type: "junit", Paths: []string{"build.env"}}},
+	))
+	assert.Equal(t, []string{"A"}, findDotenvVariables(
+		jobNode.Content[0],
+		[]ArtifactReport{{Type: "dotenv", Paths: []string{"build.env"}}},
+	))
+}
+
+func TestFindDotenvVariablesAcceptsOverwritingRedirect(t *testing.T) {
+	t.Parallel()
+
+	var jobNode yaml.Node
+	require.NoError(t, yaml.Unmarshal(
+		[]byte("script:\n  - echo \"VERSION=1\" > build.env\n  - echo \"IMAGE=alpine\" >> build.env\n"),
+		&jobNode,
+	))
+
+	assert.Equal(t, []string{"VERSION", "IMAGE"}, findDotenvVariables(
+		jobNode.Content[0],
+		[]ArtifactReport{{Type: "dotenv", Paths: []string{"build.env"}}},
+	))
+}
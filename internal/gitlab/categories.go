@@ -0,0 +1,201 @@
+package gitlab
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/erNail/labdoc/internal/config"
+)
+
+// uncategorizedCategoryName is the name of the category of components without a category.
+const uncategorizedCategoryName = "Other"
+
+// Category represents a group of components in the documentation.
+type Category struct {
+	Name        string
+	Description string
+	// Anchor is the Markdown anchor of the heading of the category.
+	Anchor string
+	// ComponentNames are the names of the components of the category. The components are available via
+	// ComponentByName of the documentation.
+	ComponentNames []string
+}
+
+// assignCategories sets the category of each component. The `@group` annotation of the
+// component takes precedence over the configured categories.
+//
+// Parameters:
+//   - components: A slice of Component structs.
+//   - categoryConfigs: The configured categories.
+func assignCategories(components []Component, categoryConfigs []config.CategoryConfig) {
+	for index := range components {
+		component := &components[index]
+		component.Category = component.Annotations.Group
+
+		for _, categoryConfig := range categoryConfigs {
+			if component.Category != "" {
+				break
+			}
+
+			if config.MatchesAny(categoryConfig.Components, component.Name) {
+				component.Category = categoryConfig.Name
+			}
+		}
+	}
+}
+
+// buildCategories groups the components by their category. Configured categories come first,
+// followed by all other categories in alphabetical order. Components without a category are
+// grouped in a last category, unless a category with the same heading exists.
+//
+// Parameters:
+//   - components: A slice of Component structs with assigned categories.
+//   - categoryConfigs: The configured categories.
+//
+// Returns:
+//   - []Category: The categories with their components, or nil if no component has a category.
+func buildCategories(components []Component, categoryConfigs []config.CategoryConfig) []Category {
+	categoryNames := []string{}
+
+	for _, component := range components {
+		if component.Category != "" && !slices.Contains(categoryNames, component.Category) {
+			categoryNames = append(categoryNames, component.Category)
+		}
+	}
+
+	if len(categoryNames) == 0 {
+		return nil
+	}
+
+	slices.Sort(categoryNames)
+
+	categories := []Category{}
+
+	for _, categoryConfig := range categoryConfigs {
+		if slices.Contains(categoryNames, categoryConfig.Name) {
+			categories = append(categories, newCategory(categoryConfig.Name, categoryConfig.Description, components))
+		}
+	}
+
+	for _, categoryName := range categoryNames {
+		isConfigured := slices.ContainsFunc(categoryConfigs, func(categoryConfig config.CategoryConfig) bool {
+			return categoryConfig.Name == categoryName
+		})
+
+		if !isConfigured {
+			categories = append(categories, newCategory(categoryName, "", components))
+		}
+	}
+
+	uncategorized := newCategory("", "", components)
+	if len(uncategorized.ComponentNames) == 0 {
+		return categories
+	}
+
+	// Components without a category join a category with the same heading, e.g. a configured "Other".
+	uncategorizedAnchor := markdownAnchor(uncategorizedCategoryName)
+	index := slices.IndexFunc(categories, func(category Category) bool {
+		return category.Anchor == uncategorizedAnchor
+	})
+
+	if index != -1 {
+		categories[index].ComponentNames = append(categories[index].ComponentNames, uncategorized.ComponentNames...)
+	} else {
+		uncategorized.Name = uncategorizedCategoryName
+		uncategorized.Anchor = uncategorizedAnchor
+		categories = append(categories, uncategorized)
+	}
+
+	return categories
+}
+
+// newCategory creates a category containing all components assigned to it.
+//
+// Parameters:
+//   - name: The name of the category.
+//   - description: The description of the category.
+//   - components: A slice of Component structs with assigned categories.
+//
+// Returns:
+//   - Category: The category with its components.
+func newCategory(name string, description string, components []Component) Category {
+	category := Category{
+		Name:           name,
+		Description:    description,
+		Anchor:         markdownAnchor(name),
+		ComponentNames: []string{},
+	}
+
+	for _, component := range components {
+		if component.Category == name {
+			category.ComponentNames = append(category.ComponentNames, component.Name)
+		}
+	}
+
+	return category
+}
+
+// assignAnchors sets the anchors of the headings of the categories and components. Headings with the
+// same anchor are de-duplicated the way GitLab and GitHub do it: the first heading keeps the anchor, later
+// headings get a "-1", "-2", ... suffix. The headings are expected in the order of the default template,
+// i.e. each category followed by its components.
+//
+// Parameters:
+//   - components: A slice of Component structs. They are modified in place.
+//   - categories: The categories of the components. They are modified in place.
+func assignAnchors(components []Component, categories []Category) {
+	usedAnchors := map[string]bool{}
+	uniqueAnchor := func(heading string) string {
+		anchor := markdownAnchor(heading)
+		for suffix := 1; usedAnchors[anchor]; suffix++ {
+			anchor = markdownAnchor(heading) + "-" + strconv.Itoa(suffix)
+		}
+
+		usedAnchors[anchor] = true
+
+		return anchor
+	}
+
+	if len(categories) == 0 {
+		for index := range components {
+			components[index].Anchor = uniqueAnchor(components[index].Name)
+		}
+
+		return
+	}
+
+	for categoryIndex := range categories {
+		categories[categoryIndex].Anchor = uniqueAnchor(categories[categoryIndex].Name)
+
+		for _, componentName := range categories[categoryIndex].ComponentNames {
+			index := slices.IndexFunc(components, func(component Component) bool {
+				return component.Name == componentName
+			})
+			components[index].Anchor = uniqueAnchor(componentName)
+		}
+	}
+}
+
+// markdownAnchor creates the anchor of a Markdown heading the way GitLab and GitHub do.
+//
+// Parameters:
+//   - heading: The text of the heading.
+//
+// Returns:
+//   - string: The anchor, e.g. "build-and-test" for "Build and Test".
+func markdownAnchor(heading string) string {
+	var anchor strings.Builder
+
+	for _, character := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(character), unicode.IsDigit(character), character == '-', character == '_':
+			anchor.WriteRune(character)
+		case character == ' ':
+			anchor.WriteRune('-')
+		}
+	}
+
+	return anchor.String()
+}
@@ -0,0 +1,114 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/erNail/labdoc/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignCategoriesPrefersAnnotationOverConfiguration(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{
+		{Name: "build-go", Annotations: Annotations{Group: "Go"}},
+		{Name: "build-docker"},
+		{Name: "deploy"},
+	}
+
+	categoryConfigs := []config.CategoryConfig{{Name: "Build", Components: []string{"build-*"}}}
+
+	assignCategories(components, categoryConfigs)
+	assert.Equal(t, "Go", components[0].Category)
+	assert.Equal(t, "Build", components[1].Category)
+	assert.Empty(t, components[2].Category)
+}
+
+func TestBuildCategoriesOrdersConfiguredCategoriesFirst(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{
+		{Name: "a", Category: "Test"},
+		{Name: "b", Category: "Build"},
+		{Name: "c", Category: "Deploy"},
+		{Name: "d"},
+		{Name: "e", Category: "Build"},
+	}
+
+	categoryConfigs := []config.CategoryConfig{
+		{Name: "Unused"},
+		{Name: "Deploy", Description: "Deploys the project"},
+	}
+
+	categories := buildCategories(components, categoryConfigs)
+	require.Len(t, categories, 4)
+
+	assert.Equal(t, "Deploy", categories[0].Name)
+	assert.Equal(t, "Deploys the project", categories[0].Description)
+	assert.Equal(t, "Build", categories[1].Name)
+	assert.Equal(t, []string{"b", "e"}, categories[1].ComponentNames)
+	assert.Equal(t, "Test", categories[2].Name)
+	assert.Equal(t, "Other", categories[3].Name)
+	assert.Equal(t, "other", categories[3].Anchor)
+	assert.Equal(t, []string{"d"}, categories[3].ComponentNames)
+}
+
+func TestBuildCategoriesMergesUncategorizedComponentsIntoOtherCategory(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{
+		{Name: "a", Category: "Build"},
+		{Name: "b", Category: "Other"},
+		{Name: "c"},
+	}
+
+	categories := buildCategories(components, nil)
+	require.Len(t, categories, 2)
+
+	assert.Equal(t, "Other", categories[1].Name)
+	assert.Equal(t, []string{"b", "c"}, categories[1].ComponentNames)
+}
+
+func TestBuildCategoriesReturnsNilWithoutCategories(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, buildCategories([]Component{{Name: "a"}}, nil))
+}
+
+func TestAssignAnchorsDeduplicatesCategoryAndComponentAnchors(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{
+		{Name: "build", Category: "Build"},
+		{Name: "build-1", Category: "Build"},
+		{Name: "go.lint", Category: "Lint"},
+	}
+	categories := buildCategories(components, nil)
+
+	assignAnchors(components, categories)
+
+	assert.Equal(t, "build", categories[0].Anchor)
+	assert.Equal(t, "build-1", components[0].Anchor)
+	assert.Equal(t, "build-1-1", components[1].Anchor)
+	assert.Equal(t, "lint", categories[1].Anchor)
+	assert.Equal(t, "golint", components[2].Anchor)
+}
+
+func TestAssignAnchorsWithoutCategoriesUsesComponentNames(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{{Name: "build"}, {Name: "go.lint"}}
+
+	assignAnchors(components, nil)
+
+	assert.Equal(t, "build", components[0].Anchor)
+	assert.Equal(t, "golint", components[1].Anchor)
+}
+
+func TestMarkdownAnchorCreatesGitLabCompatibleAnchors(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "build-and-test", markdownAnchor("Build and Test"))
+	assert.Equal(t, "ci_cd-tools", markdownAnchor("CI_CD Tools!"))
+}
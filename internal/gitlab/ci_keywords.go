@@ -0,0 +1,102 @@
+package gitlab
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ciKeywordsGitLabVersion is the GitLab version of the CI/CD YAML syntax reference
+// that topLevelKeywords is based on. Update both together.
+const ciKeywordsGitLabVersion = "17.0"
+
+// topLevelKeyword describes a global keyword of the GitLab CI/CD YAML syntax.
+type topLevelKeyword struct {
+	// Deprecated is true if GitLab recommends using the keyword in `default:` instead.
+	Deprecated bool
+}
+
+// topLevelKeywords are all global keywords of the GitLab CI/CD YAML syntax.
+// Every other top-level key defines a job.
+var topLevelKeywords = map[string]topLevelKeyword{
+	"spec":          {},
+	"default":       {},
+	"include":       {},
+	"stages":        {},
+	"variables":     {},
+	"workflow":      {},
+	"image":         {Deprecated: true},
+	"services":      {Deprecated: true},
+	"cache":         {Deprecated: true},
+	"before_script": {Deprecated: true},
+	"after_script":  {Deprecated: true},
+}
+
+// topLevelKeyKind is the classification of a top-level key of a GitLab CI/CD configuration.
+type topLevelKeyKind int
+
+const (
+	// topLevelKeyKindInvalid is a key that is neither a keyword nor a valid job definition.
+	topLevelKeyKindInvalid topLevelKeyKind = iota
+	// topLevelKeyKindKeyword is a global keyword, like `variables` or `workflow`.
+	topLevelKeyKindKeyword
+	// topLevelKeyKindJob is a job that is added to the pipeline.
+	topLevelKeyKindJob
+	// topLevelKeyKindHiddenJob is a job starting with a dot, which is never run but can be extended.
+	topLevelKeyKindHiddenJob
+	// topLevelKeyKindAnchor is a key starting with a dot that is not a mapping, e.g. a list of
+	// script lines that is only defined to be reused via a YAML anchor.
+	topLevelKeyKindAnchor
+)
+
+// classifyTopLevelKey classifies a top-level key of a GitLab CI/CD configuration.
+//
+// Parameters:
+//   - key: The top-level key.
+//   - valueNode: The value node of the key.
+//
+// Returns:
+//   - topLevelKeyKind: The classification of the key.
+func classifyTopLevelKey(key string, valueNode yaml.Node) topLevelKeyKind {
+	if isGlobalKeyword(key) {
+		return topLevelKeyKindKeyword
+	}
+
+	if isHiddenJobName(key) {
+		if valueNode.Kind != yaml.MappingNode {
+			return topLevelKeyKindAnchor
+		}
+
+		return topLevelKeyKindHiddenJob
+	}
+
+	if valueNode.Kind != yaml.MappingNode {
+		return topLevelKeyKindInvalid
+	}
+
+	return topLevelKeyKindJob
+}
+
+// isGlobalKeyword checks if a top-level key is a global keyword.
+//
+// Parameters:
+//   - key: The top-level key.
+//
+// Returns:
+//   - bool: True if the key is a global keyword.
+func isGlobalKeyword(key string) bool {
+	_, exists := topLevelKeywords[key]
+
+	return exists
+}
+
+// isHiddenJobName checks if a job name defines a hidden job.
+//
+// Parameters:
+//   - name: The name of the job.
+//
+// Returns:
+//   - bool: True if the job is hidden.
+func isHiddenJobName(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
@@ -2,13 +2,16 @@ package gitlab
 
 import (
 	"bytes"
+	"cmp"
 	"embed"
 	"errors"
 	"fmt"
 	"io/fs"
 	"slices"
+	"strings"
 	"text/template"
 
+	"github.com/erNail/labdoc/internal/config"
 	"github.com/erNail/labdoc/internal/yamlutils"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
@@ -19,6 +22,11 @@ type ComponentsDocumentation struct {
 	RepoURL    string
 	Version    string
 	Components []Component
+	// Categories groups the components by category. Empty if no component has a category.
+	Categories []Category
+	// IncludesHidden is true if components, inputs and jobs hidden from the documentation and components
+	// of all statuses are included, e.g. in a baseline for the lint rules.
+	IncludesHidden bool
 }
 
 // DocumentationGenerator defines the interface for generating documentation.
@@ -30,7 +38,10 @@ type DocumentationGenerator interface {
 		repoURL string,
 		componentVersion string,
 		outputFilePath string,
-		checkOnly bool)
+		checkOnly bool,
+		strict bool,
+		configFilePath string,
+		componentStatuses []string)
 }
 
 // RealDocumentationGenerator implements the DocumentationGenerator interface.
@@ -47,6 +58,11 @@ type RealDocumentationGenerator struct{}
 //   - componentVersion: The version or ref of the components to document.
 //   - outputFilePath: The path where the generated documentation will be saved.
 //   - checkOnly: If true, checks if the documentation is up-to-date without writing the file.
+//   - strict: If true, the template is checked for unknown fields, missing map keys cause an error,
+//     and the application exits if an input is invalid, e.g. if its default is not one of its options.
+//   - configFilePath: The path to the labdoc configuration file. Defaults are used if it does not exist.
+//   - componentStatuses: Only components with one of the statuses are documented.
+//     If empty, the statuses of the configuration file are used.
 func (r *RealDocumentationGenerator) GenerateDocumentation(
 	filesystem afero.Fs,
 	componentDirectory string,
@@ -55,9 +71,82 @@ func (r *RealDocumentationGenerator) GenerateDocumentation(
 	componentVersion string,
 	outputFilePath string,
 	checkOnly bool,
+	strict bool,
+	configFilePath string,
+	componentStatuses []string,
 ) {
 	log.Info("Generating documentation...")
 
+	configuration, err := config.LoadConfig(filesystem, configFilePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(componentStatuses) > 0 {
+		configuration.Statuses = componentStatuses
+	}
+
+	err = validateStatuses(configuration.Statuses)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	components := readComponentsFromDirectory(filesystem, componentDirectory)
+	if strict {
+		reportInvalidInputs(components)
+	}
+
+	componentsDocumentation := buildComponentDocumentationFromComponents(
+		components,
+		repoURL,
+		componentVersion,
+		configuration,
+	)
+	documentationContent := renderDocumentationContent(componentsDocumentation, templateFilePath, filesystem, strict)
+
+	if checkOnly {
+		err = compareExistingDocumentation(filesystem, outputFilePath, documentationContent)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		writeDocumentation(filesystem, outputFilePath, documentationContent)
+	}
+}
+
+// buildComponentDocumentationFromDirectory reads all components from the given
+// directory and creates a ComponentsDocumentation struct from them.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - componentDirectory: The directory containing the component YAML files.
+//   - repoURL: The URL of the repository containing the components.
+//   - version: The version or ref of the components to document.
+//   - configuration: The labdoc configuration.
+//
+// Returns:
+//   - ComponentsDocumentation: The constructed ComponentsDocumentation struct.
+func buildComponentDocumentationFromDirectory(
+	filesystem afero.Fs,
+	componentDirectory string,
+	repoURL string,
+	version string,
+	configuration config.Config,
+) ComponentsDocumentation {
+	components := readComponentsFromDirectory(filesystem, componentDirectory)
+
+	return buildComponentDocumentationFromComponents(components, repoURL, version, configuration)
+}
+
+// readComponentsFromDirectory reads and parses all components from the given directory.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - componentDirectory: The directory containing the component YAML files.
+//
+// Returns:
+//   - []Component: The parsed components, in no particular order.
+func readComponentsFromDirectory(filesystem afero.Fs, componentDirectory string) []Component {
 	filePathContentMap := yamlutils.ReadYamlFilesFromDirectory(filesystem, componentDirectory)
 	if len(filePathContentMap) == 0 {
 		log.WithField("componentsDir", componentDirectory).Fatal("No files found in directory")
@@ -66,23 +155,14 @@ func (r *RealDocumentationGenerator) GenerateDocumentation(
 	components := []Component{}
 
 	for filePath, componentFileContent := range filePathContentMap {
-		gitlabCiConfig := parseYamlFileWithoutSeparatorsToGitLabCiConfig(componentFileContent)
+		gitlabCiConfig := parseComponentFile(filesystem, filePath, componentFileContent)
 		component := newComponentFromGitLabCiConfig(gitlabCiConfig, generateComponentNameFromFilePath(filePath))
 		components = append(components, component)
 	}
 
 	log.WithField("componentCount", len(components)).Info("Found components")
-	componentsDocumentation := buildComponentDocumentationFromComponents(components, repoURL, componentVersion)
-	documentationContent := renderDocumentationContent(componentsDocumentation, templateFilePath, filesystem)
 
-	if checkOnly {
-		err := compareExistingDocumentation(filesystem, outputFilePath, documentationContent)
-		if err != nil {
-			log.Fatal(err)
-		}
-	} else {
-		writeDocumentation(filesystem, outputFilePath, documentationContent)
-	}
+	return components
 }
 
 // buildComponentDocumentationFromComponents creates a ComponentsDocumentation
@@ -92,6 +172,7 @@ func (r *RealDocumentationGenerator) GenerateDocumentation(
 //   - components: A slice of Component structs to document.
 //   - repoURL: The URL of the repository containing the components.
 //   - version: The version or ref of the components to document.
+//   - configuration: The labdoc configuration.
 //
 // Returns:
 //   - ComponentsDocumentation: The constructed ComponentsDocumentation struct.
@@ -99,22 +180,86 @@ func buildComponentDocumentationFromComponents(
 	components []Component,
 	repoURL string,
 	version string,
+	configuration config.Config,
+) ComponentsDocumentation {
+	components = removeHiddenElements(components, configuration.Hidden)
+	components = filterComponentsByStatus(components, configuration.Statuses)
+
+	return buildUnfilteredComponentDocumentation(components, repoURL, version, configuration)
+}
+
+// buildUnfilteredComponentDocumentation creates a ComponentsDocumentation struct like
+// buildComponentDocumentationFromComponents, but keeps hidden elements and components of all statuses.
+//
+// Parameters:
+//   - components: A slice of Component structs to document.
+//   - repoURL: The URL of the repository containing the components.
+//   - version: The version or ref of the components to document.
+//   - configuration: The labdoc configuration.
+//
+// Returns:
+//   - ComponentsDocumentation: The constructed ComponentsDocumentation struct.
+func buildUnfilteredComponentDocumentation(
+	components []Component,
+	repoURL string,
+	version string,
+	configuration config.Config,
 ) ComponentsDocumentation {
 	components = sortComponents(components)
-	for _, component := range components {
-		component.Inputs = sortInputs(component.Inputs)
+	for index := range components {
+		component := &components[index]
+		component.Inputs = applyInputCommentPolicy(sortInputs(component.Inputs), configuration.Inputs.CommentPolicy)
 		component.Jobs = sortJobs(component.Jobs)
+		component.HiddenJobs = sortJobs(component.HiddenJobs)
+		component.Variables = applyVariableComments(component.Variables)
+		applyScriptsConfig(component.Jobs, configuration.Scripts)
+		applyScriptsConfig(component.HiddenJobs, configuration.Scripts)
+
+		for _, job := range slices.Concat(component.Jobs, component.HiddenJobs) {
+			applyVariableComments(job.Variables)
+		}
+
+		component.UsageVariants = buildUsageVariants(*component, repoURL, version, configuration.Usage.References)
+		if len(component.UsageVariants) > 0 {
+			component.UsageExample = component.UsageVariants[0].UsageExample
+			component.FullUsageExample = component.UsageVariants[0].FullUsageExample
+		}
+		component.HasOptionalInputs = hasOptionalInputs(*component)
+		component.PipelineDiagram = buildPipelineDiagram(component.Jobs, component.Stages)
 	}
 
+	assignCategories(components, configuration.Categories)
+	categories := buildCategories(components, configuration.Categories)
+	assignAnchors(components, categories)
+
 	componentDocumentation := ComponentsDocumentation{
 		Components: components,
 		RepoURL:    repoURL,
 		Version:    version,
+		Categories: categories,
 	}
 
 	return componentDocumentation
 }
 
+// ComponentByName returns the component with the given name, e.g. to render the components of a category.
+//
+// Parameters:
+//   - name: The name of the component.
+//
+// Returns:
+//   - Component: The component. Empty if no component has the name.
+func (componentsDocumentation ComponentsDocumentation) ComponentByName(name string) Component {
+	index := slices.IndexFunc(componentsDocumentation.Components, func(component Component) bool {
+		return component.Name == name
+	})
+	if index == -1 {
+		return Component{}
+	}
+
+	return componentsDocumentation.Components[index]
+}
+
 // renderDocumentationContent renders the documentation content using the
 // specified template and component documentation data.
 //
@@ -122,6 +267,7 @@ func buildComponentDocumentationFromComponents(
 //   - componentsDocumentation: The data for the components to document.
 //   - templateFilePath: The path to the template file used for generating documentation.
 //   - filesystem: An interface for interacting with the file system.
+//   - strict: If true, the template is checked for unknown fields and missing map keys cause an error.
 //
 // Returns:
 //   - string: The rendered documentation content.
@@ -129,10 +275,24 @@ func renderDocumentationContent(
 	componentsDocumentation ComponentsDocumentation,
 	templateFilePath string,
 	filesystem afero.Fs,
+	strict bool,
 ) string {
 	templateFileContent := readTemplateFile(templateFilePath, filesystem)
 
-	tmpl, err := template.New(templateFilePath).Parse(templateFileContent)
+	tmpl := template.New(templateFilePath)
+
+	if strict {
+		fieldErrors, err := checkTemplateFields(templateFilePath, templateFileContent)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		reportTemplateFieldErrors(fieldErrors)
+
+		tmpl = tmpl.Option("missingkey=error")
+	}
+
+	tmpl, err := tmpl.Parse(templateFileContent)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -227,7 +387,7 @@ func compareExistingDocumentation(filesystem afero.Fs, outputFilePath string, ne
 	return nil
 }
 
-// sortJobs sorts a slice of Job structs by their name.
+// sortJobs sorts a slice of Job structs by their name, as resolved with the input defaults.
 //
 // Parameters:
 //   - jobs: A slice of Job structs.
@@ -236,18 +396,137 @@ func compareExistingDocumentation(filesystem afero.Fs, outputFilePath string, ne
 //   - []Job: The sorted slice of Job structs.
 func sortJobs(jobs []Job) []Job {
 	slices.SortFunc(jobs, func(a Job, b Job) int {
-		if a.Name < b.Name {
-			return -1
+		return cmp.Compare(cmp.Or(a.ResolvedName, a.Name), cmp.Or(b.ResolvedName, b.Name))
+	})
+
+	return jobs
+}
+
+// filterComponentsByStatus removes all components whose status is not one of the given statuses.
+// Components without a status are treated as stable.
+//
+// Parameters:
+//   - components: A slice of Component structs.
+//   - allowedStatuses: The statuses of the components to keep. If empty, all components are kept.
+//
+// Returns:
+//   - []Component: The slice of Component structs with one of the statuses.
+func filterComponentsByStatus(components []Component, allowedStatuses []string) []Component {
+	if len(allowedStatuses) == 0 {
+		return components
+	}
+
+	return slices.DeleteFunc(components, func(component Component) bool {
+		status := component.Annotations.Status
+		if status == "" {
+			status = StatusStable
 		}
 
-		if a.Name > b.Name {
-			return 1
+		return !slices.Contains(allowedStatuses, status)
+	})
+}
+
+// validateStatuses checks that all statuses are supported values of the `@status` annotation.
+//
+// Parameters:
+//   - statusesToValidate: The statuses to check.
+//
+// Returns:
+//   - error: An error if a status is not supported.
+func validateStatuses(statusesToValidate []string) error {
+	for _, status := range statusesToValidate {
+		if !slices.Contains(statuses, status) {
+			return fmt.Errorf("unknown status %q, must be one of %v", status, statuses)
 		}
+	}
 
-		return 0
+	return nil
+}
+
+// removeHiddenElements removes all components, inputs and jobs from the documentation that
+// are marked with `labdoc:hidden` or `@internal` or match one of the configured patterns.
+//
+// Parameters:
+//   - components: A slice of Component structs.
+//   - hidden: The configured patterns of hidden elements.
+//
+// Returns:
+//   - []Component: The slice of Component structs without hidden elements.
+func removeHiddenElements(components []Component, hidden config.HiddenConfig) []Component {
+	components = slices.DeleteFunc(components, func(component Component) bool {
+		return component.Annotations.IsHidden() || config.MatchesAny(hidden.Components, component.Name)
 	})
 
-	return jobs
+	isHiddenJob := func(job Job) bool {
+		return job.Annotations.IsHidden() || config.MatchesAny(hidden.Jobs, job.Name)
+	}
+
+	for index := range components {
+		component := &components[index]
+		component.Jobs = slices.DeleteFunc(component.Jobs, isHiddenJob)
+		component.HiddenJobs = slices.DeleteFunc(component.HiddenJobs, isHiddenJob)
+		component.Inputs = slices.DeleteFunc(component.Inputs, func(input Input) bool {
+			isHidden := input.Annotations.IsHidden() || config.MatchesAny(hidden.Inputs, input.Name)
+			if isHidden && isMandatoryInput(input) {
+				log.WithFields(log.Fields{
+					"component": component.Name,
+					"input":     input.Name,
+				}).Warn("Hiding mandatory input. The usage examples will not set it")
+			}
+
+			return isHidden
+		})
+	}
+
+	return components
+}
+
+// applyInputCommentPolicy sets the descriptions of the inputs based on their comments.
+// Line breaks of the comments are replaced by spaces, since descriptions are usually rendered in tables.
+//
+// Parameters:
+//   - inputs: A slice of Input structs.
+//   - commentPolicy: One of the comment policies of the configuration, e.g. "fallback".
+//
+// Returns:
+//   - []Input: The slice of Input structs with updated descriptions.
+func applyInputCommentPolicy(inputs []Input, commentPolicy string) []Input {
+	for index := range inputs {
+		input := &inputs[index]
+		comment := strings.Join(strings.Fields(input.Comment), " ")
+
+		if comment == "" {
+			continue
+		}
+
+		switch commentPolicy {
+		case config.CommentPolicyFallback:
+			if input.Description == "" {
+				input.Description = comment
+			}
+		case config.CommentPolicyAppend:
+			input.Description = strings.TrimSpace(input.Description + " " + comment)
+		}
+	}
+
+	return inputs
+}
+
+// applyVariableComments uses the comments of variables without a description as their description.
+// Whitespace of the descriptions is collapsed, so they fit into a table row.
+//
+// Parameters:
+//   - variables: A slice of Variable structs. The variables are modified in place.
+//
+// Returns:
+//   - []Variable: The slice of Variable structs with updated descriptions.
+func applyVariableComments(variables []Variable) []Variable {
+	for index := range variables {
+		variable := &variables[index]
+		variable.Description = strings.Join(strings.Fields(cmp.Or(variable.Description, variable.Comment)), " ")
+	}
+
+	return variables
 }
 
 // sortInputs sorts a slice of Input structs by their name.
@@ -3,6 +3,7 @@ package gitlab
 import (
 	"testing"
 
+	"github.com/erNail/labdoc/internal/config"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -87,6 +88,9 @@ spec:
 		"1.0.0",
 		"README.md",
 		false,
+		false,
+		".labdoc.yml",
+		nil,
 	)
 
 	outputExists, err := afero.Exists(filesystem, outputFilePath)
@@ -150,7 +154,8 @@ First Component
 		"```yaml\n" +
 		"include:\n" +
 		"  - component: \"github.com/test/first-component@1.0.0\"\n" +
-		"    inputs: {}\n" +
+		"    inputs:\n" +
+		"      stage: \"<stage>\"\n" +
 		"```\n" +
 		`
 You can configure the component with the inputs documented below.
@@ -166,6 +171,13 @@ You can configure the component with the inputs documented below.
 		`
 The component will add the following jobs to your CI/CD Pipeline.
 ` +
+		"\n```mermaid\n" +
+		"flowchart LR\n" +
+		"  subgraph stage0[\"test\"]\n" +
+		"    job0[\"first-component-first-job\"]\n" +
+		"    job1[\"first-component-second-job\"]\n" +
+		"  end\n" +
+		"```\n" +
 		"\n##### `first-component-first-job`\n" +
 		`
 First Component first job
@@ -185,7 +197,8 @@ Second Component
 		"```yaml\n" +
 		"include:\n" +
 		"  - component: \"github.com/test/second-component@1.0.0\"\n" +
-		"    inputs: {}\n" +
+		"    inputs:\n" +
+		"      stage: \"<stage>\"\n" +
 		"```\n" +
 		`
 You can configure the component with the inputs documented below.
@@ -201,6 +214,13 @@ You can configure the component with the inputs documented below.
 		`
 The component will add the following jobs to your CI/CD Pipeline.
 ` +
+		"\n```mermaid\n" +
+		"flowchart LR\n" +
+		"  subgraph stage0[\"test\"]\n" +
+		"    job0[\"second-component-first-job\"]\n" +
+		"    job1[\"second-component-second-job\"]\n" +
+		"  end\n" +
+		"```\n" +
 		"\n##### `second-component-first-job`\n" +
 		`
 Second Component first job
@@ -232,6 +252,9 @@ Second Component second job
 		"1.0.0",
 		"README.md",
 		false,
+		false,
+		".labdoc.yml",
+		nil,
 	)
 
 	outputExists, err := afero.Exists(filesystem, outputFilePath)
@@ -281,7 +304,10 @@ Description: {{ $component.Description }}
 		"github.com/test",
 		"1.0.0",
 		"README.md",
-		false)
+		false,
+		false,
+		".labdoc.yml",
+		nil)
 
 	outputExists, err := afero.Exists(filesystem, outputFilePath)
 	require.NoError(t, err)
@@ -325,10 +351,34 @@ func TestBuildComponentDocumentationFromComponentsBuildsSortedComponentDocumenta
 	repoURL := "https://example.com/repo"
 	version := "1.0.0"
 
+	usageExample := func(componentName string) string {
+		return "include:\n" +
+			"  - component: \"https://example.com/repo/" + componentName + "@1.0.0\"\n" +
+			"    inputs:\n" +
+			"      InputA: \"<InputA>\"\n" +
+			"      InputB: \"<InputB>\"\n"
+	}
+	pipelineDiagram := "flowchart LR\n" +
+		"  subgraph stage0[\"test\"]\n" +
+		"    job0[\"JobA\"]\n" +
+		"    job1[\"JobB\"]\n" +
+		"  end\n"
+	usageVariants := func(componentName string) []UsageVariant {
+		return []UsageVariant{
+			{
+				Title:            "Pinned version",
+				Reference:        "https://example.com/repo/" + componentName + "@1.0.0",
+				UsageExample:     usageExample(componentName),
+				FullUsageExample: usageExample(componentName),
+			},
+		}
+	}
+
 	// Expected sorted components, inputs, and jobs
 	expectedComponents := []Component{
 		{
 			Name:        "ComponentA",
+			Anchor:      "componenta",
 			Description: "First component",
 			Inputs: []Input{
 				{Name: "InputA", Description: "First input"},
@@ -338,9 +388,14 @@ func TestBuildComponentDocumentationFromComponentsBuildsSortedComponentDocumenta
 				{Name: "JobA", Comment: "First job"},
 				{Name: "JobB", Comment: "Second job"},
 			},
+			UsageExample:     usageExample("ComponentA"),
+			FullUsageExample: usageExample("ComponentA"),
+			UsageVariants:    usageVariants("ComponentA"),
+			PipelineDiagram:  pipelineDiagram,
 		},
 		{
 			Name:        "ComponentB",
+			Anchor:      "componentb",
 			Description: "Second component",
 			Inputs: []Input{
 				{Name: "InputA", Description: "First input"},
@@ -350,6 +405,10 @@ func TestBuildComponentDocumentationFromComponentsBuildsSortedComponentDocumenta
 				{Name: "JobA", Comment: "First job"},
 				{Name: "JobB", Comment: "Second job"},
 			},
+			UsageExample:     usageExample("ComponentB"),
+			FullUsageExample: usageExample("ComponentB"),
+			UsageVariants:    usageVariants("ComponentB"),
+			PipelineDiagram:  pipelineDiagram,
 		},
 	}
 
@@ -359,7 +418,7 @@ func TestBuildComponentDocumentationFromComponentsBuildsSortedComponentDocumenta
 		Version:    version,
 	}
 
-	result := buildComponentDocumentationFromComponents(components, repoURL, version)
+	result := buildComponentDocumentationFromComponents(components, repoURL, version, config.NewDefaultConfig())
 
 	assert.Equal(t, expected, result)
 }
@@ -468,7 +527,7 @@ Description: Description1
 Description: Description2
 
 `
-	actualContent := renderDocumentationContent(componentsDocumentation, templateFilePath, filesystem)
+	actualContent := renderDocumentationContent(componentsDocumentation, templateFilePath, filesystem, false)
 	assert.Equal(t, expectedContent, actualContent)
 }
 
@@ -495,6 +554,22 @@ func TestSortJobsCorrectlySortsJobs(t *testing.T) {
 	assert.Equal(t, expectedJobs, jobs)
 }
 
+func TestSortJobsSortsByResolvedName(t *testing.T) {
+	t.Parallel()
+
+	jobs := []Job{
+		{Name: "$[[ inputs.prefix ]]-build", ResolvedName: "docs-build"},
+		{Name: "check", ResolvedName: "check"},
+		{Name: "test"},
+	}
+
+	sortJobs(jobs)
+
+	assert.Equal(t, "check", jobs[0].Name)
+	assert.Equal(t, "$[[ inputs.prefix ]]-build", jobs[1].Name)
+	assert.Equal(t, "test", jobs[2].Name)
+}
+
 func TestSortSpecInputsCorrectlySortsInputs(t *testing.T) {
 	t.Parallel()
 
@@ -537,3 +612,530 @@ func TestSortComponentsCorrectlySortsComponents(t *testing.T) {
 
 	assert.Equal(t, expectedComponents, actualComponents)
 }
+
+func TestGenerateDocumentationRendersConfiguredUsageVariants(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+# Component
+spec:
+  inputs:
+    stage:
+      default: "test"
+...
+`
+
+	configContent := `---
+usage:
+  references:
+    - style: "pinned"
+    - style: "latest"
+      host: "$CI_SERVER_FQDN"
+...
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+	err = afero.WriteFile(filesystem, ".labdoc.yml", []byte(configContent), 0o644)
+	require.NoError(t, err)
+
+	documentationGenerator := &RealDocumentationGenerator{}
+	documentationGenerator.GenerateDocumentation(
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/group/project",
+		"1.0.0",
+		"README.md",
+		false,
+		false,
+		".labdoc.yml",
+		nil,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(outputContent), "##### Pinned version\n\n```yaml\ninclude:\n"+
+		"  - component: \"gitlab.com/group/project/component@1.0.0\"\n")
+	assert.Contains(t, string(outputContent), "##### Latest release\n\n```yaml\ninclude:\n"+
+		"  - component: \"$CI_SERVER_FQDN/group/project/component@~latest\"\n")
+}
+
+func TestGenerateDocumentationRendersHiddenJobsAndEffectiveConfiguration(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+# Component
+spec:
+  inputs: {}
+...
+---
+# Base job
+.base:
+  image: "alpine"
+# Job
+job:
+  extends: ".base"
+  script: "echo job"
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+
+	documentationGenerator := &RealDocumentationGenerator{}
+	documentationGenerator.GenerateDocumentation(
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/group/project",
+		"1.0.0",
+		"README.md",
+		false,
+		false,
+		".labdoc.yml",
+		nil,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(outputContent), "##### `job`\n\nJob\n\nExtends: `.base`\n\n<details>\n"+
+		"<summary>Effective configuration</summary>\n\n```yaml\nimage: \"alpine\"\nscript: \"echo job\"\n```\n")
+	assert.Contains(t, string(outputContent), "##### `.base`\n\nBase job\n\nExtended by: `job`\n")
+}
+
+func TestGenerateDocumentationRendersAnnotations(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+# Component
+# @deprecated Use another component.
+# @since 1.4.0
+spec:
+  inputs:
+    stage:
+      description: "The stage\n@since 1.5.0\n@example \"build\""
+      default: "test"
+    # The variables
+    # @example
+    #   A: 1
+    #   B: 2
+    variables:
+      type: "string"
+      default: ""
+...
+---
+# Job
+# @example
+#   job:
+#     stage: "build"
+job: {}
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+
+	documentationGenerator := &RealDocumentationGenerator{}
+	documentationGenerator.GenerateDocumentation(
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/group/project",
+		"1.0.0",
+		"README.md",
+		false,
+		false,
+		".labdoc.yml",
+		nil,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(outputContent), "### component\n\n"+
+		"![Status: deprecated](https://img.shields.io/badge/status-deprecated-red)\n\n"+
+		"> **Deprecated**: Use another component.\n\n"+
+		"Component\n\n_Available since 1.4.0._\n")
+	assert.Contains(t, string(outputContent), "| `stage` | The stage<br>_Since 1.5.0_<br>Example: `\"build\"` |")
+	assert.Contains(t, string(outputContent), "| `variables` | The variables<br>Example: <code>A: 1<br>B: 2</code> |")
+	assert.Contains(t, string(outputContent), "##### `job`\n\nJob\n\nExample:\n\n"+
+		"```yaml\njob:\n  stage: \"build\"\n```\n")
+}
+
+func TestApplyInputCommentPolicySetsDescriptions(t *testing.T) {
+	t.Parallel()
+
+	newInputs := func() []Input {
+		return []Input{
+			{Name: "described", Description: "Description", Comment: "Extended\ndocs"},
+			{Name: "undescribed", Comment: "Comment"},
+			{Name: "uncommented", Description: "Description"},
+		}
+	}
+
+	fallbackInputs := applyInputCommentPolicy(newInputs(), config.CommentPolicyFallback)
+	assert.Equal(t, "Description", fallbackInputs[0].Description)
+	assert.Equal(t, "Comment", fallbackInputs[1].Description)
+	assert.Equal(t, "Description", fallbackInputs[2].Description)
+
+	appendInputs := applyInputCommentPolicy(newInputs(), config.CommentPolicyAppend)
+	assert.Equal(t, "Description Extended docs", appendInputs[0].Description)
+	assert.Equal(t, "Comment", appendInputs[1].Description)
+	assert.Equal(t, "Description", appendInputs[2].Description)
+
+	ignoreInputs := applyInputCommentPolicy(newInputs(), config.CommentPolicyIgnore)
+	assert.Equal(t, "Description", ignoreInputs[0].Description)
+	assert.Empty(t, ignoreInputs[1].Description)
+}
+
+func TestBuildComponentDocumentationFromComponentsRemovesHiddenElements(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{
+		{
+			Name: "component",
+			Inputs: []Input{
+				{Name: "stage", Default: "test"},
+				{Name: "test-input", Default: "value"},
+				{Name: "marked-input", Default: "value", Annotations: Annotations{Undocumented: true}},
+			},
+			Jobs: []Job{
+				{Name: "job"},
+				{Name: "marked-job", Annotations: Annotations{Undocumented: true}},
+			},
+			HiddenJobs: []Job{{Name: ".internal-template"}},
+		},
+		{Name: "test-component"},
+		{Name: "marked-component", Annotations: Annotations{Undocumented: true}},
+	}
+
+	configuration := config.NewDefaultConfig()
+	configuration.Hidden = config.HiddenConfig{
+		Components: []string{"test-*"},
+		Inputs:     []string{"test-*"},
+		Jobs:       []string{".internal-*"},
+	}
+
+	documentation := buildComponentDocumentationFromComponents(
+		components,
+		"gitlab.com/group/project",
+		"1.0.0",
+		configuration,
+	)
+
+	require.Len(t, documentation.Components, 1)
+	assert.Equal(t, "component", documentation.Components[0].Name)
+	assert.Equal(t, []Input{{Name: "stage", Default: "test"}}, documentation.Components[0].Inputs)
+	assert.Equal(t, []Job{{Name: "job"}}, documentation.Components[0].Jobs)
+	assert.Empty(t, documentation.Components[0].HiddenJobs)
+}
+
+func TestGenerateDocumentationExcludesInternalElements(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+spec:
+  inputs:
+    stage:
+      default: "test"
+    # @internal
+    debug-mode:
+      default: false
+...
+---
+job: {}
+# Only used by the tests of the component.
+# @internal
+.test-setup:
+  script: "echo setup"
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+	err = afero.WriteFile(
+		filesystem, "templates/helper.yml", []byte("# @internal\nspec:\n  inputs: {}\n---\njob: {}\n"), 0o644,
+	)
+	require.NoError(t, err)
+
+	documentationGenerator := &RealDocumentationGenerator{}
+	documentationGenerator.GenerateDocumentation(
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/group/project",
+		"1.0.0",
+		"README.md",
+		false,
+		false,
+		".labdoc.yml",
+		nil,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(outputContent), "### component\n")
+	assert.Contains(t, string(outputContent), "| `stage` |")
+	assert.Contains(t, string(outputContent), "##### `job`\n")
+	assert.NotContains(t, string(outputContent), "helper")
+	assert.NotContains(t, string(outputContent), "debug-mode")
+	assert.NotContains(t, string(outputContent), ".test-setup")
+}
+
+func TestGenerateDocumentationRendersCategories(t *testing.T) {
+	t.Parallel()
+
+	buildComponentContent := `---
+# Builds the project
+# @group Build
+spec:
+  inputs: {}
+...
+---
+job: {}
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/build.yml", []byte(buildComponentContent), 0o644)
+	require.NoError(t, err)
+	err = afero.WriteFile(filesystem, "templates/lint.yml", []byte("spec:\n  inputs: {}\n---\njob: {}\n"), 0o644)
+	require.NoError(t, err)
+
+	documentationGenerator := &RealDocumentationGenerator{}
+	documentationGenerator.GenerateDocumentation(
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/group/project",
+		"1.0.0",
+		"README.md",
+		false,
+		false,
+		".labdoc.yml",
+		nil,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(outputContent), "The following components are available in this repository:\n\n"+
+		"- [Build](#build)\n  - [build](#build-1)\n\n- [Other](#other)\n  - [lint](#lint)\n")
+	assert.Contains(t, string(outputContent), "## Build\n\n### build\n\nBuilds the project\n")
+	assert.Contains(t, string(outputContent), "## Other\n\n### lint\n")
+}
+
+func TestFilterComponentsByStatusTreatsMissingStatusAsStable(t *testing.T) {
+	t.Parallel()
+
+	newComponents := func() []Component {
+		return []Component{
+			{Name: "experimental", Annotations: Annotations{Status: StatusExperimental}},
+			{Name: "beta", Annotations: Annotations{Status: StatusBeta}},
+			{Name: "without-status"},
+		}
+	}
+
+	filteredComponents := filterComponentsByStatus(newComponents(), []string{StatusStable, StatusBeta})
+	assert.Equal(t, []Component{
+		{Name: "beta", Annotations: Annotations{Status: StatusBeta}},
+		{Name: "without-status"},
+	}, filteredComponents)
+
+	assert.Len(t, filterComponentsByStatus(newComponents(), nil), 3)
+}
+
+func TestValidateStatusesReturnsErrorOnUnknownStatus(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateStatuses([]string{StatusStable, StatusDeprecated}))
+	require.Error(t, validateStatuses([]string{"alpha"}))
+}
+
+func TestGenerateDocumentationRendersGlobalAndJobVariables(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+# Component
+spec:
+  inputs: {}
+...
+---
+variables:
+  # The log
+  # level
+  LOG_LEVEL:
+    value: "info"
+    options: ["info", "debug"]
+# Job
+job:
+  variables:
+    DEPLOY_ENV: "prod" # The target environment
+  script: "echo job"
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+
+	documentationGenerator := &RealDocumentationGenerator{}
+	documentationGenerator.GenerateDocumentation(
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/group/project",
+		"1.0.0",
+		"README.md",
+		false,
+		true,
+		".labdoc.yml",
+		nil,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(outputContent), "##### `job`\n\nJob\n\nVariables of job `job`:\n\n"+
+		"| Name | Value | Options | Description |\n| ---- | ----- | ------- | ----------- |\n"+
+		"| `DEPLOY_ENV` | `prod` | - | The target environment |\n")
+	assert.Contains(t, string(outputContent), "| `LOG_LEVEL` | `info` | `info`, `debug` | The log level |\n")
+}
+
+func TestGenerateDocumentationRendersRunSummaries(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+spec:
+  inputs: {}
+...
+---
+workflow:
+  rules:
+    - if: $CI_PIPELINE_SOURCE == "merge_request_event"
+# Job
+job:
+  rules:
+    - if: $CI_COMMIT_TAG
+      when: manual
+  script: "echo job"
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+
+	documentationGenerator := &RealDocumentationGenerator{}
+	documentationGenerator.GenerateDocumentation(
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/group/project",
+		"1.0.0",
+		"README.md",
+		false,
+		true,
+		".labdoc.yml",
+		nil,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(outputContent), "##### `job`\n\nJob\n\nRuns manually on tags; does not run otherwise.\n")
+	assert.Contains(t, string(outputContent),
+		"##### Workflow\n\nCreates pipelines on merge requests; creates no pipelines otherwise.\n\n```yaml\n")
+}
+
+func TestGenerateDocumentationRendersProducedArtifacts(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+spec:
+  inputs: {}
+...
+---
+build:
+  script:
+    - echo "VERSION=1.0.0" >> build.env
+  artifacts:
+    paths: ["dist/"]
+    expire_in: "1 day"
+    reports:
+      dotenv: "build.env"
+test:
+  script: "echo test"
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+
+	documentationGenerator := &RealDocumentationGenerator{}
+	documentationGenerator.GenerateDocumentation(
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/group/project",
+		"1.0.0",
+		"README.md",
+		false,
+		true,
+		".labdoc.yml",
+		nil,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(outputContent), "#### Produced by component `component`\n\n"+
+		"The jobs of the component produce the following artifacts and reports.\n\n"+
+		"| Job | Paths | Reports | Exposed as | Expires in |\n| --- | ----- | ------- | ---------- | ---------- |\n"+
+		"| `build` | `dist/` | dotenv: `build.env` | - | 1 day |\n\n"+
+		"The following variables are passed to downstream jobs via `dotenv` reports:\n\n"+
+		"- `VERSION` from job `build`\n")
+}
+
+func TestGenerateDocumentationRendersJobScripts(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+spec:
+  inputs: {}
+...
+---
+build:
+  before_script: "apk add make"
+  script:
+    - make build
+    - make test
+    - make package
+  after_script: "printf '` + "```" + `'"
+# @script hide
+test:
+  script: "make test"
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+	err = afero.WriteFile(filesystem, ".labdoc.yml", []byte("scripts: {show: true, maxLines: 2}\n"), 0o644)
+	require.NoError(t, err)
+
+	documentationGenerator := &RealDocumentationGenerator{}
+	documentationGenerator.GenerateDocumentation(
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/group/project",
+		"1.0.0",
+		"README.md",
+		false,
+		true,
+		".labdoc.yml",
+		nil,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(outputContent), "<details>\n<summary>Script of job `build`</summary>\n\n"+
+		"`before_script`:\n\n```shell\napk add make\n```\n\n"+
+		"`script`:\n\n```shell\nmake build\nmake test\n# ... 1 more line\n```\n\n"+
+		"`after_script`:\n\n````shell\nprintf '```'\n````\n\n</details>\n")
+	assert.NotContains(t, string(outputContent), "Script of job `test`")
+}
@@ -0,0 +1,271 @@
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/erNail/labdoc/internal/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// fullCoverage is the coverage of components without anything to document.
+const fullCoverage = 100.0
+
+// ComponentCoverage is the documentation coverage of a single component.
+type ComponentCoverage struct {
+	Name string
+	// HasDescription is true if the component has a comment above its `spec`.
+	HasDescription bool
+	// DocumentedInputs is the number of inputs with a description.
+	DocumentedInputs int
+	TotalInputs      int
+	// DocumentedJobs is the number of jobs and hidden jobs with a comment.
+	DocumentedJobs int
+	TotalJobs      int
+}
+
+// Documented returns the number of documented elements of the component.
+func (coverage ComponentCoverage) Documented() int {
+	documented := coverage.DocumentedInputs + coverage.DocumentedJobs
+	if coverage.HasDescription {
+		documented++
+	}
+
+	return documented
+}
+
+// Total returns the number of elements of the component that should be documented.
+func (coverage ComponentCoverage) Total() int {
+	return 1 + coverage.TotalInputs + coverage.TotalJobs
+}
+
+// Percentage returns the percentage of documented elements of the component.
+func (coverage ComponentCoverage) Percentage() float64 {
+	return coveragePercentage(coverage.Documented(), coverage.Total())
+}
+
+// CoverageReport is the documentation coverage of all components.
+type CoverageReport struct {
+	Components []ComponentCoverage
+}
+
+// Percentage returns the percentage of documented elements of all components.
+func (report CoverageReport) Percentage() float64 {
+	documented, total := 0, 0
+
+	for _, componentCoverage := range report.Components {
+		documented += componentCoverage.Documented()
+		total += componentCoverage.Total()
+	}
+
+	return coveragePercentage(documented, total)
+}
+
+// CoverageReporter defines the interface for reporting the documentation coverage of components.
+type CoverageReporter interface {
+	ReportCoverage(
+		filesystem afero.Fs,
+		writer io.Writer,
+		componentDirectory string,
+		configFilePath string,
+		minCoverage float64,
+		badgeFilePath string,
+	)
+}
+
+// RealCoverageReporter implements the CoverageReporter interface.
+type RealCoverageReporter struct{}
+
+// ReportCoverage writes the documentation coverage of each component. Elements that are
+// hidden from the documentation are not counted.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - writer: The writer to which the report is written.
+//   - componentDirectory: The directory containing the component YAML files.
+//   - configFilePath: The path to the labdoc configuration file. Defaults are used if it does not exist.
+//   - minCoverage: The minimum coverage in percent. If the coverage is lower, the application exits.
+//   - badgeFilePath: The path of an SVG badge showing the coverage. If empty, no badge is written.
+func (r *RealCoverageReporter) ReportCoverage(
+	filesystem afero.Fs,
+	writer io.Writer,
+	componentDirectory string,
+	configFilePath string,
+	minCoverage float64,
+	badgeFilePath string,
+) {
+	configuration, err := config.LoadConfig(filesystem, configFilePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	componentsDocumentation := buildComponentDocumentationFromDirectory(
+		filesystem,
+		componentDirectory,
+		"",
+		"",
+		configuration,
+	)
+	report := buildCoverageReport(componentsDocumentation.Components)
+
+	err = writeCoverageReport(writer, report)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if badgeFilePath != "" {
+		err = afero.WriteFile(filesystem, badgeFilePath, []byte(buildCoverageBadge(report.Percentage())), 0o644)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.WithField("filePath", badgeFilePath).Info("Wrote coverage badge")
+	}
+
+	if report.Percentage() < minCoverage {
+		log.WithFields(log.Fields{
+			"coverage":    fmt.Sprintf("%.1f%%", report.Percentage()),
+			"minCoverage": fmt.Sprintf("%.1f%%", minCoverage),
+		}).Fatal("Documentation coverage is below the minimum")
+	}
+}
+
+// buildCoverageReport computes the documentation coverage of the components.
+//
+// Parameters:
+//   - components: The documented components.
+//
+// Returns:
+//   - CoverageReport: The coverage of each component.
+func buildCoverageReport(components []Component) CoverageReport {
+	report := CoverageReport{Components: []ComponentCoverage{}}
+
+	for _, component := range components {
+		componentCoverage := ComponentCoverage{
+			Name:           component.Name,
+			HasDescription: component.Description != "",
+			TotalInputs:    len(component.Inputs),
+			TotalJobs:      len(component.Jobs) + len(component.HiddenJobs),
+		}
+
+		for _, input := range component.Inputs {
+			if input.Description != "" {
+				componentCoverage.DocumentedInputs++
+			}
+		}
+
+		for _, job := range slices.Concat(component.Jobs, component.HiddenJobs) {
+			if job.Comment != "" {
+				componentCoverage.DocumentedJobs++
+			}
+		}
+
+		report.Components = append(report.Components, componentCoverage)
+	}
+
+	return report
+}
+
+// writeCoverageReport writes the coverage report as table.
+//
+// Parameters:
+//   - writer: The writer to which the report is written.
+//   - report: The coverage report.
+//
+// Returns:
+//   - error: An error if writing fails.
+func writeCoverageReport(writer io.Writer, report CoverageReport) error {
+	tableWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	_, err := fmt.Fprintln(tableWriter, "COMPONENT\tDESCRIPTION\tINPUTS\tJOBS\tCOVERAGE")
+	if err != nil {
+		return fmt.Errorf("failed to write coverage report: %w", err)
+	}
+
+	for _, componentCoverage := range report.Components {
+		description := "no"
+		if componentCoverage.HasDescription {
+			description = "yes"
+		}
+
+		_, err = fmt.Fprintf(
+			tableWriter,
+			"%s\t%s\t%d/%d\t%d/%d\t%.1f%%\n",
+			componentCoverage.Name,
+			description,
+			componentCoverage.DocumentedInputs,
+			componentCoverage.TotalInputs,
+			componentCoverage.DocumentedJobs,
+			componentCoverage.TotalJobs,
+			componentCoverage.Percentage(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to write coverage report: %w", err)
+		}
+	}
+
+	_, err = fmt.Fprintf(tableWriter, "TOTAL\t\t\t\t%.1f%%\n", report.Percentage())
+	if err != nil {
+		return fmt.Errorf("failed to write coverage report: %w", err)
+	}
+
+	err = tableWriter.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write coverage report: %w", err)
+	}
+
+	return nil
+}
+
+// buildCoverageBadge creates an SVG badge showing the coverage, colored by the coverage.
+//
+// Parameters:
+//   - percentage: The coverage in percent.
+//
+// Returns:
+//   - string: The SVG badge.
+func buildCoverageBadge(percentage float64) string {
+	color := "#e05d44"
+
+	switch {
+	case percentage >= 90: //nolint:mnd
+		color = "#4c1"
+	case percentage >= 75: //nolint:mnd
+		color = "#a3c51c"
+	case percentage >= 50: //nolint:mnd
+		color = "#dfb317"
+	}
+
+	value := fmt.Sprintf("%.0f%%", percentage)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="144" height="20" role="img"
+  aria-label="docs coverage: %[1]s">
+  <title>docs coverage: %[1]s</title>
+  <rect width="96" height="20" fill="#555"/>
+  <rect x="96" width="48" height="20" fill="%[2]s"/>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="48" y="14">docs coverage</text>
+    <text x="120" y="14">%[1]s</text>
+  </g>
+</svg>
+`, value, color)
+}
+
+// coveragePercentage computes a percentage. Nothing to document is full coverage.
+//
+// Parameters:
+//   - documented: The number of documented elements.
+//   - total: The number of elements.
+//
+// Returns:
+//   - float64: The percentage of documented elements.
+func coveragePercentage(documented int, total int) float64 {
+	if total == 0 {
+		return fullCoverage
+	}
+
+	return float64(documented) * fullCoverage / float64(total)
+}
@@ -0,0 +1,118 @@
+package gitlab
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCoverageReportCountsDocumentedElements(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{
+		{
+			Name:        "documented",
+			Description: "Description",
+			Inputs:      []Input{{Name: "input", Description: "Description"}},
+			Jobs:        []Job{{Name: "job", Comment: "Comment"}},
+		},
+		{
+			Name:       "undocumented",
+			Inputs:     []Input{{Name: "input"}, {Name: "other", Description: "Description"}},
+			HiddenJobs: []Job{{Name: ".job"}},
+		},
+	}
+
+	report := buildCoverageReport(components)
+
+	require.Len(t, report.Components, 2)
+	assert.InDelta(t, 100.0, report.Components[0].Percentage(), 0.01)
+	assert.Equal(t, ComponentCoverage{
+		Name:             "undocumented",
+		DocumentedInputs: 1,
+		TotalInputs:      2,
+		TotalJobs:        1,
+	}, report.Components[1])
+	assert.InDelta(t, 25.0, report.Components[1].Percentage(), 0.01)
+	assert.InDelta(t, 57.14, report.Percentage(), 0.01)
+}
+
+func TestCoverageReportWithoutComponentsIsFullyCovered(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, 100.0, CoverageReport{}.Percentage(), 0.01)
+}
+
+func TestWriteCoverageReportWritesTable(t *testing.T) {
+	t.Parallel()
+
+	report := CoverageReport{Components: []ComponentCoverage{
+		{Name: "component", HasDescription: true, DocumentedInputs: 1, TotalInputs: 2, TotalJobs: 1},
+	}}
+
+	buffer := new(bytes.Buffer)
+	require.NoError(t, writeCoverageReport(buffer, report))
+
+	expectedReport := `COMPONENT  DESCRIPTION  INPUTS  JOBS  COVERAGE
+component  yes          1/2     0/1   50.0%
+TOTAL                                 50.0%
+`
+	assert.Equal(t, expectedReport, buffer.String())
+}
+
+// failingWriter is a writer that always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteCoverageReportReturnsErrorIfWritingFails(t *testing.T) {
+	t.Parallel()
+
+	require.Error(t, writeCoverageReport(failingWriter{}, CoverageReport{}))
+}
+
+func TestBuildCoverageBadgeUsesColorOfCoverage(t *testing.T) {
+	t.Parallel()
+
+	assert.Contains(t, buildCoverageBadge(95), `fill="#4c1"`)
+	assert.Contains(t, buildCoverageBadge(95), ">95%</text>")
+	assert.Contains(t, buildCoverageBadge(80), `fill="#a3c51c"`)
+	assert.Contains(t, buildCoverageBadge(60), `fill="#dfb317"`)
+	assert.Contains(t, buildCoverageBadge(10), `fill="#e05d44"`)
+}
+
+func TestReportCoverageWritesReportAndBadge(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+# Component
+spec:
+  inputs:
+    stage:
+      description: "The stage"
+...
+---
+# Job
+job: {}
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	coverageReporter := &RealCoverageReporter{}
+	coverageReporter.ReportCoverage(filesystem, buffer, "templates", ".labdoc.yml", 100, "coverage.svg")
+
+	assert.Contains(t, buffer.String(), "component  yes          1/1     1/1   100.0%")
+
+	badgeContent, err := afero.ReadFile(filesystem, "coverage.svg")
+	require.NoError(t, err)
+	assert.Contains(t, string(badgeContent), "docs coverage: 100%")
+}
@@ -0,0 +1,325 @@
+package gitlab
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/erNail/labdoc/internal/yamlutils"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// referenceTag is the YAML tag GitLab uses to reuse configuration from other sections.
+	referenceTag = "!reference"
+	// maxExtendsDepth is the maximum nesting of `extends` that GitLab supports.
+	maxExtendsDepth = 11
+	// maxReferenceDepth is the maximum nesting of `!reference` tags that GitLab supports.
+	maxReferenceDepth = 10
+)
+
+// jobConfigResolver resolves `extends` and `!reference` within a single configuration file.
+type jobConfigResolver struct {
+	topLevelNodes map[string]*yaml.Node
+}
+
+// newJobConfigResolver creates a jobConfigResolver for the top-level mapping node of a configuration file.
+//
+// Parameters:
+//   - documentNode: The top-level mapping node of the configuration file.
+//
+// Returns:
+//   - *jobConfigResolver: The resolver for the configuration file.
+func newJobConfigResolver(documentNode *yaml.Node) *jobConfigResolver {
+	resolver := &jobConfigResolver{topLevelNodes: map[string]*yaml.Node{}}
+
+	for i := 0; i+1 < len(documentNode.Content); i += 2 {
+		resolver.topLevelNodes[documentNode.Content[i].Value] = documentNode.Content[i+1]
+	}
+
+	return resolver
+}
+
+// resolveJob returns the effective configuration of a job, with all parents of `extends`
+// merged into it and all `!reference` tags replaced by the referenced configuration.
+// Parents that are not defined in the file, e.g. because they are set via an input, are skipped.
+//
+// Parameters:
+//   - name: The name of the job.
+//
+// Returns:
+//   - *yaml.Node: The effective configuration of the job, without the `extends` keyword.
+//   - []string: All parents that were merged, in the order in which they were merged.
+//   - bool: True if at least one `!reference` tag was resolved.
+//   - error: An error if the `extends` chain is circular or too deep.
+func (r *jobConfigResolver) resolveJob(name string) (*yaml.Node, []string, bool, error) {
+	extendedNode, extendsChain, err := r.resolveExtends(name, []string{})
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	effectiveNode, usesReferences := r.resolveReferences(extendedNode, 0)
+
+	return effectiveNode, extendsChain, usesReferences, nil
+}
+
+// resolveExtends merges all parents of a job into the job. Parents are merged in order,
+// so later parents and finally the job itself override earlier parents.
+//
+// Parameters:
+//   - name: The name of the job.
+//   - path: The jobs that are currently being resolved, used to detect circular extends.
+//
+// Returns:
+//   - *yaml.Node: The configuration of the job with all parents merged.
+//   - []string: All parents that were merged, in the order in which they were merged.
+//   - error: An error if the `extends` chain is circular or too deep.
+func (r *jobConfigResolver) resolveExtends(name string, path []string) (*yaml.Node, []string, error) {
+	for _, pathName := range path {
+		if pathName == name {
+			return nil, nil, fmt.Errorf("circular extends in job %q", name)
+		}
+	}
+
+	if len(path) > maxExtendsDepth {
+		return nil, nil, fmt.Errorf("extends of job %q is nested deeper than %d levels", name, maxExtendsDepth)
+	}
+
+	jobNode := r.topLevelNodes[name]
+	mergedNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	var extendsChain []string
+
+	for _, parent := range extendsOfJob(jobNode) {
+		if _, exists := r.topLevelNodes[parent]; !exists {
+			log.WithFields(log.Fields{"job": name, "extends": parent}).Debug("Parent of job is not defined in file")
+
+			continue
+		}
+
+		parentNode, parentChain, err := r.resolveExtends(parent, append(slices.Clone(path), name))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		mergedNode = deepMergeNodes(mergedNode, parentNode)
+		extendsChain = append(extendsChain, parentChain...)
+		extendsChain = append(extendsChain, parent)
+	}
+
+	mergedNode = deepMergeNodes(mergedNode, withoutMappingKey(jobNode, "extends"))
+
+	return mergedNode, extendsChain, nil
+}
+
+// resolveReferences replaces all `!reference` tags in the node with a copy of the referenced configuration.
+// A reference inside a sequence that points to a sequence is flattened into the outer sequence.
+//
+// Parameters:
+//   - node: The node in which to resolve references.
+//   - depth: The current nesting of references.
+//
+// Returns:
+//   - *yaml.Node: A copy of the node with all references resolved.
+//   - bool: True if at least one reference was resolved.
+func (r *jobConfigResolver) resolveReferences(node *yaml.Node, depth int) (*yaml.Node, bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	if isReferenceNode(node) {
+		referencedNode := r.lookupReference(node)
+		if referencedNode == nil || depth >= maxReferenceDepth {
+			log.WithField("reference", referencePath(node)).Warn("Could not resolve !reference")
+
+			return yamlutils.CopyNode(node), false
+		}
+
+		resolvedNode, _ := r.resolveReferences(referencedNode, depth+1)
+
+		return resolvedNode, true
+	}
+
+	resolvedNode := *node
+	resolvedNode.Content = []*yaml.Node{}
+	usesReferences := false
+
+	for _, child := range node.Content {
+		resolvedChild, childUsesReferences := r.resolveReferences(child, depth)
+		usesReferences = usesReferences || childUsesReferences
+
+		// References that could not be resolved are kept as they are.
+		isResolvedReference := isReferenceNode(child) && !isReferenceNode(resolvedChild)
+		if node.Kind == yaml.SequenceNode && isResolvedReference && resolvedChild.Kind == yaml.SequenceNode {
+			resolvedNode.Content = append(resolvedNode.Content, resolvedChild.Content...)
+
+			continue
+		}
+
+		resolvedNode.Content = append(resolvedNode.Content, resolvedChild)
+	}
+
+	return &resolvedNode, usesReferences
+}
+
+// lookupReference returns the node that a `!reference` tag points to.
+//
+// Parameters:
+//   - referenceNode: The sequence node tagged with `!reference`.
+//
+// Returns:
+//   - *yaml.Node: The referenced node, or nil if it does not exist.
+func (r *jobConfigResolver) lookupReference(referenceNode *yaml.Node) *yaml.Node {
+	path := referencePath(referenceNode)
+	if len(path) == 0 {
+		return nil
+	}
+
+	currentNode := r.topLevelNodes[path[0]]
+
+	for _, key := range path[1:] {
+		currentNode = mappingValue(currentNode, key)
+	}
+
+	return currentNode
+}
+
+// isReferenceNode checks if a node is tagged with `!reference`.
+//
+// Parameters:
+//   - node: The node to check.
+//
+// Returns:
+//   - bool: True if the node is a `!reference`.
+func isReferenceNode(node *yaml.Node) bool {
+	return node.Kind == yaml.SequenceNode && node.Tag == referenceTag
+}
+
+// referencePath returns the keys of a `!reference` tag, e.g. [".setup", "script"].
+//
+// Parameters:
+//   - referenceNode: The sequence node tagged with `!reference`.
+//
+// Returns:
+//   - []string: The keys of the reference.
+func referencePath(referenceNode *yaml.Node) []string {
+	path := []string{}
+	for _, keyNode := range referenceNode.Content {
+		path = append(path, keyNode.Value)
+	}
+
+	return path
+}
+
+// extendsOfJob returns the parents of a job, as set via the `extends` keyword.
+//
+// Parameters:
+//   - jobNode: The mapping node of the job.
+//
+// Returns:
+//   - []string: The parents of the job, or nil if the job does not use `extends`.
+func extendsOfJob(jobNode *yaml.Node) []string {
+	extendsNode := mappingValue(jobNode, "extends")
+	if extendsNode == nil {
+		return nil
+	}
+
+	if extendsNode.Kind == yaml.ScalarNode {
+		return []string{extendsNode.Value}
+	}
+
+	var parents []string
+
+	for _, parentNode := range extendsNode.Content {
+		if parentNode.Kind == yaml.ScalarNode {
+			parents = append(parents, parentNode.Value)
+		}
+	}
+
+	return parents
+}
+
+// deepMergeNodes merges two nodes the way GitLab merges `extends`. Mappings are merged
+// recursively, while all other values of the override replace the ones of the base.
+//
+// Parameters:
+//   - base: The node that is overridden.
+//   - override: The node whose values take precedence.
+//
+// Returns:
+//   - *yaml.Node: A new node containing the merged configuration.
+func deepMergeNodes(base *yaml.Node, override *yaml.Node) *yaml.Node {
+	if base == nil || base.Kind != yaml.MappingNode || override.Kind != yaml.MappingNode {
+		return yamlutils.CopyNode(override)
+	}
+
+	mergedNode := yamlutils.CopyNode(base)
+
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key := override.Content[i].Value
+		overrideValue := override.Content[i+1]
+		replaced := false
+
+		for j := 0; j+1 < len(mergedNode.Content); j += 2 {
+			if mergedNode.Content[j].Value == key {
+				mergedNode.Content[j+1] = deepMergeNodes(mergedNode.Content[j+1], overrideValue)
+				replaced = true
+
+				break
+			}
+		}
+
+		if !replaced {
+			mergedNode.Content = append(
+				mergedNode.Content,
+				yamlutils.CopyNode(override.Content[i]),
+				yamlutils.CopyNode(overrideValue),
+			)
+		}
+	}
+
+	return mergedNode
+}
+
+// withoutMappingKey returns a copy of a mapping node without the given key.
+//
+// Parameters:
+//   - mappingNode: The mapping node.
+//   - key: The key to remove.
+//
+// Returns:
+//   - *yaml.Node: A copy of the mapping node without the key.
+func withoutMappingKey(mappingNode *yaml.Node, key string) *yaml.Node {
+	nodeCopy := yamlutils.CopyNode(mappingNode)
+	nodeCopy.Content = []*yaml.Node{}
+
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		if mappingNode.Content[i].Value != key {
+			nodeCopy.Content = append(nodeCopy.Content, mappingNode.Content[i], mappingNode.Content[i+1])
+		}
+	}
+
+	return nodeCopy
+}
+
+// mappingValue returns the value of a key in a mapping node.
+//
+// Parameters:
+//   - mappingNode: The mapping node.
+//   - key: The key to look up.
+//
+// Returns:
+//   - *yaml.Node: The value of the key, or nil if the node is not a mapping or does not contain the key.
+func mappingValue(mappingNode *yaml.Node, key string) *yaml.Node {
+	if mappingNode == nil || mappingNode.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		if mappingNode.Content[i].Value == key {
+			return mappingNode.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/erNail/labdoc/internal/yamlutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func newTestJobConfigResolver(t *testing.T, yamlContent string) *jobConfigResolver {
+	t.Helper()
+
+	var documentNode yaml.Node
+
+	err := yaml.Unmarshal([]byte(yamlContent), &documentNode)
+	require.NoError(t, err)
+
+	return newJobConfigResolver(documentNode.Content[0])
+}
+
+func TestResolveJobResolvesNestedExtendsChain(t *testing.T) {
+	t.Parallel()
+
+	resolver := newTestJobConfigResolver(t, `---
+.root:
+  stage: "build"
+.middle:
+  extends: ".root"
+  script: "echo middle"
+job:
+  extends: ".middle"
+  script: "echo job"
+`)
+
+	effectiveNode, extendsChain, usesReferences, err := resolver.resolveJob("job")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".root", ".middle"}, extendsChain)
+	assert.False(t, usesReferences)
+	assert.Equal(t, "stage: \"build\"\nscript: \"echo job\"\n", yamlutils.FormatNodeAsYaml(*effectiveNode))
+}
+
+func TestResolveJobReturnsErrorOnCircularExtends(t *testing.T) {
+	t.Parallel()
+
+	resolver := newTestJobConfigResolver(t, `---
+.first:
+  extends: ".second"
+.second:
+  extends: ".first"
+job:
+  extends: ".first"
+`)
+
+	_, _, _, err := resolver.resolveJob("job")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "circular extends")
+}
+
+func TestResolveJobKeepsUnresolvableReferences(t *testing.T) {
+	t.Parallel()
+
+	resolver := newTestJobConfigResolver(t, `---
+job:
+  script: !reference [".missing", "script"]
+`)
+
+	effectiveNode, _, usesReferences, err := resolver.resolveJob("job")
+	require.NoError(t, err)
+	assert.False(t, usesReferences)
+	assert.Equal(t, referenceTag, mappingValue(effectiveNode, "script").Tag)
+}
+
+func TestDeepMergeNodesMergesMappingsAndReplacesSequences(t *testing.T) {
+	t.Parallel()
+
+	var base, override yaml.Node
+
+	require.NoError(t, yaml.Unmarshal([]byte("variables:\n  A: \"1\"\nscript:\n  - \"base\"\n"), &base))
+	require.NoError(t, yaml.Unmarshal([]byte("variables:\n  B: \"2\"\nscript:\n  - \"override\"\n"), &override))
+
+	mergedNode := deepMergeNodes(base.Content[0], override.Content[0])
+	assert.Equal(
+		t,
+		"variables:\n  A: \"1\"\n  B: \"2\"\nscript:\n  - \"override\"\n",
+		yamlutils.FormatNodeAsYaml(*mergedNode),
+	)
+}
@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"fmt"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -12,14 +13,29 @@ import (
 
 // CiConfig represents the GitLab CI configuration.
 type CiConfig struct {
-	Spec Spec  `yaml:"spec"`
-	Jobs []Job `yaml:"-"`
+	Spec               Spec       `yaml:"spec"`
+	Jobs               []Job      `yaml:"-"`
+	HiddenJobs         []Job      `yaml:"-"`
+	Stages             []string   `yaml:"-"`
+	Variables          []Variable `yaml:"-"`
+	Default            string     `yaml:"-"`
+	Workflow           string     `yaml:"-"`
+	WorkflowRules      []Rule     `yaml:"-"`
+	DeprecatedKeywords []string   `yaml:"-"`
+	// Images are the container images of the `default` keyword and the global `image` and `services` keywords.
+	Images []ContainerImage `yaml:"-"`
+	// Includes are the entries of the `include` keyword, followed by the non-local includes of included files.
+	Includes []Include `yaml:"-"`
+	// InputInterpolations are the interpolated inputs in the `image`, `before_script` and `after_script`
+	// keywords of `default` and of the top level. Keywords of `default` are prefixed with "default:".
+	InputInterpolations []InputInterpolation `yaml:"-"`
 }
 
 // Spec defines the "spec" keyword of the GitLab CI configuration.
 type Spec struct {
-	Inputs  []Input `yaml:"inputs"`
-	Comment string  `yaml:"-"`
+	Inputs      []Input     `yaml:"inputs"`
+	Comment     string      `yaml:"-"`
+	Annotations Annotations `yaml:"-"`
 }
 
 // Input represents an input parameter for the GitLab CI spec.
@@ -30,12 +46,82 @@ type Input struct {
 	Default     interface{}   `yaml:"default,omitempty"`
 	Options     []interface{} `yaml:"options,omitempty"`
 	Regex       string        `yaml:"regex,omitempty"`
+	// Comment is the head and line comment of the input key.
+	Comment string `yaml:"-"`
+	// Annotations are the annotations of the description and the comment, like `@deprecated`.
+	Annotations Annotations `yaml:"-"`
 }
 
 // Job represents a job in the GitLab CI configuration.
 type Job struct {
+	// Name is the name of the job as written in the file, e.g. "$[[ inputs.prefix ]]-build".
 	Name    string
 	Comment string
+	// ResolvedName is the name with the interpolated inputs replaced by their defaults, e.g. "docs-build".
+	// Interpolations of inputs without a default are kept.
+	ResolvedName string
+	// NameInputs are the inputs interpolated into the name. They control the name of the job.
+	NameInputs []string
+	// Source is the path of the locally included file that defines the job.
+	// Empty if the job is defined in the component file.
+	Source string
+	// Annotations are the annotations of the comment, like `@deprecated`.
+	Annotations Annotations
+	// Stage is the stage of the job. Empty if the job does not set a stage.
+	Stage string
+	// Needs are the names of the jobs this job needs.
+	Needs []string
+	// Dependencies are the names of the jobs this job fetches artifacts from.
+	Dependencies []string
+	// Hidden is true if the job starts with a dot. Hidden jobs are never run, but can be extended.
+	Hidden bool
+	// Extends are the parents of the job, as set via the `extends` keyword.
+	Extends []string
+	// ExtendsChain are all parents defined in the component or its local includes, in the order in which
+	// they are merged.
+	ExtendsChain []string
+	// ExtendedBy are the jobs of the component or its local includes that extend this job.
+	// Once the job is part of a component, the names are resolved with the input defaults like ResolvedName.
+	ExtendedBy []string
+	// UsesReferences is true if the job uses at least one resolvable `!reference` tag.
+	UsesReferences bool
+	// EffectiveConfig is the YAML configuration of the job after resolving `extends` and `!reference`.
+	EffectiveConfig string
+	// InputInterpolations are the interpolated inputs in the `script`, `before_script`, `after_script`,
+	// `rules:if` and `image` keywords of the effective configuration.
+	InputInterpolations []InputInterpolation
+	// Images are the container images of the `image` and `services` keywords of the effective configuration.
+	Images []ContainerImage
+	// Variables are the variables of the effective configuration, in the order of their definition.
+	Variables []Variable
+	// Rules are the entries of the `rules` keyword.
+	Rules []Rule
+	// Only is the deprecated `only` keyword. Nil if it is not set.
+	Only *RefFilter
+	// Except is the deprecated `except` keyword. Nil if it is not set.
+	Except *RefFilter
+	// When is the `when` keyword. Empty if it is not set.
+	When string
+	// Artifacts are the artifacts of the job. Nil if the job has no artifacts.
+	Artifacts *Artifacts
+	// Scripts are the `before_script`, `script` and `after_script` of the effective configuration.
+	Scripts []JobScript
+	// ShowScripts is true if the scripts are rendered in the documentation.
+	ShowScripts bool
+	// RunSummary describes when the job runs, e.g. "Runs on merge requests; does not run otherwise.".
+	// Empty if the job runs in every pipeline.
+	RunSummary string
+}
+
+// Variable represents a variable defined via the `variables` keyword.
+type Variable struct {
+	Name        string
+	Value       string
+	Description string
+	// Options are the values offered when running a pipeline manually, as set via the long form.
+	Options []string
+	// Comment is the head and line comment of the variable key.
+	Comment string
 }
 
 // Component represents a GitLab CI component.
@@ -43,7 +129,50 @@ type Component struct {
 	Jobs        []Job
 	Description string
 	Name        string
-	Inputs      []Input
+	// Anchor is the Markdown anchor of the heading of the component. It differs from the name if the
+	// heading of a category has the same anchor.
+	Anchor string
+	Inputs []Input
+	// Annotations are the annotations of the spec comment, like `@deprecated`.
+	Annotations Annotations
+	// Category is the category of the component, from the `@group` annotation or the configuration.
+	Category string
+	// HiddenJobs are the jobs starting with a dot. They are never run, but can be extended.
+	HiddenJobs []Job
+	// Stages are the stages defined via the `stages` keyword of the component.
+	Stages []string
+	// Variables are the global variables of the component.
+	Variables []Variable
+	// Default is the YAML content of the `default` keyword of the component.
+	Default string
+	// Workflow is the YAML content of the `workflow` keyword of the component.
+	Workflow string
+	// WorkflowRules are the entries of `workflow:rules`.
+	WorkflowRules []Rule
+	// WorkflowSummary describes when pipelines are created, based on `workflow:rules`.
+	WorkflowSummary string
+	// DeprecatedKeywords are the deprecated global keywords used by the component, like a global `image`.
+	DeprecatedKeywords []string
+	// Images are the container images of the `default` keyword, the global keywords and all jobs,
+	// including hidden jobs.
+	Images []ContainerImage
+	// InputInterpolations are the interpolated inputs in the `image`, `before_script` and `after_script`
+	// keywords of `default` and of the top level. Keywords of `default` are prefixed with "default:".
+	InputInterpolations []InputInterpolation
+	// IncludedComponents are the components included via `include:component`, including those of
+	// locally included files. They add their jobs to the pipeline, too.
+	IncludedComponents []string
+	// PipelineDiagram is a Mermaid flowchart of the jobs, grouped by stage and connected by needs.
+	PipelineDiagram string
+	// UsageExample is an include snippet that sets all mandatory inputs.
+	UsageExample string
+	// FullUsageExample is an include snippet that additionally lists all optional inputs as comments.
+	FullUsageExample string
+	// UsageVariants contains the usage examples for every configured reference style.
+	// UsageExample and FullUsageExample are the ones of the first variant.
+	UsageVariants []UsageVariant
+	// HasOptionalInputs is true if at least one input has a default value.
+	HasOptionalInputs bool
 }
 
 // UnmarshalYAML is called when using yaml.Unmarshal on a GitLabCiConfig type.
@@ -55,93 +184,379 @@ type Component struct {
 //   - error: An error if unmarshalling fails.
 func (gitlabCiConfig *CiConfig) UnmarshalYAML(node *yaml.Node) error {
 	gitlabCiConfig.Jobs = []Job{}
+	anchorOnlyCandidates := findAliasedTopLevelKeys(node)
+
+	node, err := yamlutils.ResolveAliases(node)
+	if err != nil {
+		return err
+	}
+
+	resolver := newJobConfigResolver(node)
+	gitlabCiConfig.Images = slices.Concat(
+		findContainerImages(mappingValue(node, "default"), ""),
+		findContainerImages(node, ""),
+	)
+	gitlabCiConfig.InputInterpolations = findGlobalInputInterpolations(node)
 
 	for i := 0; i < len(node.Content); i += 2 {
 		keyNode := node.Content[i]
 		valueNode := node.Content[i+1]
 		key := keyNode.Value
 
-		if key == "spec" {
-			gitlabCiConfig.Spec.Inputs = parseSpecInputs(*valueNode)
-			gitlabCiConfig.Spec.Comment = yamlutils.FormatCommentAsPlainText(keyNode.HeadComment)
-		} else if isJobMappingNode(key, *valueNode) {
-			job := Job{
-				Name:    key,
-				Comment: yamlutils.FormatCommentAsPlainText(keyNode.HeadComment),
+		switch classifyTopLevelKey(key, *valueNode) {
+		case topLevelKeyKindKeyword:
+			if err := gitlabCiConfig.parseGlobalKeyword(*keyNode, *valueNode); err != nil {
+				return err
 			}
-			gitlabCiConfig.Jobs = append(gitlabCiConfig.Jobs, job)
+		case topLevelKeyKindJob, topLevelKeyKindHiddenJob:
+			job, err := resolveAndParseJob(resolver, key)
+			if err != nil {
+				return err
+			}
+
+			job.Comment, job.Annotations = parseAnnotations(yamlutils.FormatCommentAsPlainText(keyNode.HeadComment))
+
+			if isHiddenJobName(key) {
+				gitlabCiConfig.HiddenJobs = append(gitlabCiConfig.HiddenJobs, job)
+			} else {
+				gitlabCiConfig.Jobs = append(gitlabCiConfig.Jobs, job)
+			}
+		case topLevelKeyKindAnchor:
+			log.WithField("key", key).Debug("Ignoring hidden key that only holds YAML anchors")
+		case topLevelKeyKindInvalid:
+			log.WithFields(log.Fields{
+				"key":                key,
+				"knownGitLabVersion": ciKeywordsGitLabVersion,
+			}).Warn("Ignoring top-level key that is neither a known keyword nor a job")
 		}
 	}
 
+	setExtendedBy(gitlabCiConfig.Jobs, gitlabCiConfig.HiddenJobs)
+
+	// Hidden jobs that are only used via YAML aliases are not offered for extension.
+	gitlabCiConfig.HiddenJobs = slices.DeleteFunc(gitlabCiConfig.HiddenJobs, func(job Job) bool {
+		return anchorOnlyCandidates[job.Name] && len(job.ExtendedBy) == 0
+	})
+
 	return nil
 }
 
-// parseSpecInputs parses the inputs of a spec node.
+// findAliasedTopLevelKeys finds the top-level keys whose value is anchored and used by an alias.
+//
+// Parameters:
+//   - node: The top-level mapping node of the configuration file.
+//
+// Returns:
+//   - map[string]bool: The top-level keys whose value is used by an alias.
+func findAliasedTopLevelKeys(node *yaml.Node) map[string]bool {
+	aliasedAnchors := yamlutils.AliasedAnchors(node)
+	aliasedKeys := map[string]bool{}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		anchor := node.Content[i+1].Anchor
+		if anchor != "" && aliasedAnchors[anchor] {
+			aliasedKeys[node.Content[i].Value] = true
+		}
+	}
+
+	return aliasedKeys
+}
+
+// resolveAndParseJob resolves the effective configuration of a job and parses it.
+//
+// Parameters:
+//   - resolver: The resolver for the configuration file containing the job.
+//   - name: The name of the job.
+//
+// Returns:
+//   - Job: The parsed Job struct, based on the effective configuration.
+//   - error: An error if the configuration can not be resolved or parsed.
+func resolveAndParseJob(resolver *jobConfigResolver, name string) (Job, error) {
+	effectiveNode, extendsChain, usesReferences, err := resolver.resolveJob(name)
+	if err != nil {
+		return Job{}, err
+	}
+
+	job, err := parseJob(name, *effectiveNode)
+	if err != nil {
+		return Job{}, err
+	}
+
+	job.Hidden = isHiddenJobName(name)
+	job.Extends = extendsOfJob(resolver.topLevelNodes[name])
+	job.ExtendsChain = extendsChain
+	job.UsesReferences = usesReferences
+	job.EffectiveConfig = yamlutils.FormatNodeAsYaml(*effectiveNode)
+	job.InputInterpolations = findInputInterpolations(effectiveNode)
+	job.Images = findContainerImages(effectiveNode, name)
+	job.Artifacts = parseArtifacts(effectiveNode)
+	job.Scripts = parseJobScripts(effectiveNode)
+
+	if variablesNode := mappingValue(effectiveNode, "variables"); variablesNode != nil {
+		job.Variables, err = parseVariables(*variablesNode)
+		if err != nil {
+			return Job{}, fmt.Errorf("failed to parse variables of job %q: %w", name, err)
+		}
+	}
+
+	return job, nil
+}
+
+// setExtendedBy sets the jobs extending each hidden job.
+//
+// Parameters:
+//   - jobs: The jobs of the configuration file.
+//   - hiddenJobs: The hidden jobs of the configuration file.
+func setExtendedBy(jobs []Job, hiddenJobs []Job) {
+	for index := range hiddenJobs {
+		hiddenJob := &hiddenJobs[index]
+
+		for _, job := range slices.Concat(jobs, hiddenJobs) {
+			if slices.Contains(job.Extends, hiddenJob.Name) {
+				hiddenJob.ExtendedBy = append(hiddenJob.ExtendedBy, job.Name)
+			}
+		}
+	}
+}
+
+// parseGlobalKeyword parses a global keyword into the CiConfig.
+//
+// Parameters:
+//   - keyNode: The YAML node of the keyword.
+//   - valueNode: The YAML node of the value of the keyword.
+//
+// Returns:
+//   - error: An error if the value of the keyword has an unexpected format.
+func (gitlabCiConfig *CiConfig) parseGlobalKeyword(keyNode yaml.Node, valueNode yaml.Node) error {
+	key := keyNode.Value
+
+	if topLevelKeywords[key].Deprecated {
+		gitlabCiConfig.DeprecatedKeywords = append(gitlabCiConfig.DeprecatedKeywords, key)
+
+		return nil
+	}
+
+	switch key {
+	case "spec":
+		gitlabCiConfig.Spec.Inputs = parseSpecInputs(valueNode)
+		gitlabCiConfig.Spec.Comment, gitlabCiConfig.Spec.Annotations = parseAnnotations(
+			yamlutils.FormatCommentAsPlainText(keyNode.HeadComment),
+		)
+	case "stages":
+		if err := valueNode.Decode(&gitlabCiConfig.Stages); err != nil {
+			return fmt.Errorf("failed to parse stages: %w", err)
+		}
+	case "variables":
+		variables, err := parseVariables(valueNode)
+		if err != nil {
+			return err
+		}
+
+		gitlabCiConfig.Variables = variables
+	case "default":
+		gitlabCiConfig.Default = yamlutils.FormatNodeAsYaml(valueNode)
+	case "include":
+		gitlabCiConfig.Includes = parseIncludes(valueNode)
+	case "workflow":
+		gitlabCiConfig.Workflow = yamlutils.FormatNodeAsYaml(valueNode)
+
+		rules, err := parseRules(mappingValue(&valueNode, "rules"))
+		if err != nil {
+			return fmt.Errorf("failed to parse workflow: %w", err)
+		}
+
+		gitlabCiConfig.WorkflowRules = rules
+	}
+
+	return nil
+}
+
+// parseVariables parses the variables of a `variables` keyword. Both the short form
+// `NAME: value` and the long form with `value`, `description` and `options` are supported.
+// The head and line comments of each variable key are stored as comment of the variable.
+//
+// Parameters:
+//   - variablesNode: The YAML mapping node of the `variables` keyword.
+//
+// Returns:
+//   - []Variable: A slice of Variable structs, in the order of their definition.
+//   - error: An error if a variable has an unexpected format.
+func parseVariables(variablesNode yaml.Node) ([]Variable, error) {
+	variables := []Variable{}
+
+	if variablesNode.Kind != yaml.MappingNode {
+		return variables, nil
+	}
+
+	for i := 0; i < len(variablesNode.Content); i += 2 {
+		keyNode := variablesNode.Content[i]
+		valueNode := variablesNode.Content[i+1]
+		variable := Variable{Name: keyNode.Value, Comment: formatKeyComments(keyNode, valueNode)}
+
+		if valueNode.Kind == yaml.MappingNode {
+			var longForm struct {
+				Value       string   `yaml:"value"`
+				Description string   `yaml:"description"`
+				Options     []string `yaml:"options"`
+			}
+
+			if err := valueNode.Decode(&longForm); err != nil {
+				return nil, fmt.Errorf("failed to parse variable %q: %w", variable.Name, err)
+			}
+
+			variable.Value = longForm.Value
+			variable.Description = longForm.Description
+			variable.Options = longForm.Options
+		} else {
+			variable.Value = valueNode.Value
+		}
+
+		variables = append(variables, variable)
+	}
+
+	return variables, nil
+}
+
+// parseJob parses the keywords of a job that are used in the documentation.
+//
+// Parameters:
+//   - name: The name of the job.
+//   - jobNode: The YAML mapping node of the job.
+//
+// Returns:
+//   - Job: The parsed Job struct.
+//   - error: An error if a keyword has an unexpected format.
+func parseJob(name string, jobNode yaml.Node) (Job, error) {
+	var jobKeywords struct {
+		Stage        string    `yaml:"stage"`
+		Needs        yaml.Node `yaml:"needs"`
+		Dependencies yaml.Node `yaml:"dependencies"`
+		When         string    `yaml:"when"`
+	}
+
+	if err := jobNode.Decode(&jobKeywords); err != nil {
+		return Job{}, fmt.Errorf("failed to parse job %q: %w", name, err)
+	}
+
+	rules, err := parseRules(mappingValue(&jobNode, "rules"))
+	if err != nil {
+		return Job{}, fmt.Errorf("failed to parse rules of job %q: %w", name, err)
+	}
+
+	job := Job{
+		Name:   name,
+		Stage:  jobKeywords.Stage,
+		Rules:  rules,
+		Only:   parseRefFilter(mappingValue(&jobNode, "only")),
+		Except: parseRefFilter(mappingValue(&jobNode, "except")),
+		When:   jobKeywords.When,
+	}
+	job.RunSummary = summarizeJobRuns(job)
+
+	// Needs and dependencies that are set via an input are not sequences and can not be resolved.
+	// The same applies to `!reference` tags that could not be resolved.
+	if jobKeywords.Dependencies.Kind == yaml.SequenceNode && !isReferenceNode(&jobKeywords.Dependencies) {
+		for _, dependencyNode := range jobKeywords.Dependencies.Content {
+			if dependencyNode.Kind == yaml.ScalarNode {
+				job.Dependencies = append(job.Dependencies, dependencyNode.Value)
+			}
+		}
+	}
+
+	if jobKeywords.Needs.Kind != yaml.SequenceNode || isReferenceNode(&jobKeywords.Needs) {
+		return job, nil
+	}
+
+	for _, needNode := range jobKeywords.Needs.Content {
+		if isReferenceNode(needNode) {
+			continue
+		}
+
+		if needNode.Kind == yaml.ScalarNode {
+			job.Needs = append(job.Needs, needNode.Value)
+
+			continue
+		}
+
+		var need struct {
+			Job      string `yaml:"job"`
+			Pipeline string `yaml:"pipeline"`
+			Project  string `yaml:"project"`
+		}
+
+		if err := needNode.Decode(&need); err != nil {
+			return Job{}, fmt.Errorf("failed to parse needs of job %q: %w", name, err)
+		}
+
+		// Needs on other pipelines or projects do not reference a job of this pipeline.
+		if need.Job != "" && need.Pipeline == "" && need.Project == "" {
+			job.Needs = append(job.Needs, need.Job)
+		}
+	}
+
+	return job, nil
+}
+
+// parseSpecInputs parses the inputs of a spec node. The head and line comments of each
+// input key are stored as comment of the input.
 //
 // Parameters:
 //   - specNode: The YAML node containing the spec inputs.
 //
 // Returns:
-//   - []Input: A slice of Input structs.
+//   - []Input: A slice of Input structs, in the order of their definition.
 func parseSpecInputs(specNode yaml.Node) []Input {
 	inputs := []Input{}
 
-	var temp struct {
-		Inputs map[string]yaml.Node `yaml:"inputs"`
+	inputsNode := mappingValue(&specNode, "inputs")
+	if inputsNode == nil || inputsNode.Kind != yaml.MappingNode {
+		return inputs
 	}
 
-	if err := specNode.Decode(&temp); err != nil {
-		log.Fatal(err)
-	}
+	for i := 0; i+1 < len(inputsNode.Content); i += 2 {
+		keyNode := inputsNode.Content[i]
+		inputNode := inputsNode.Content[i+1]
 
-	for key, inputNode := range temp.Inputs {
 		var input Input
 		if err := inputNode.Decode(&input); err != nil {
 			log.Fatal(err)
 		}
 
-		input.Name = key
+		var descriptionAnnotations, commentAnnotations Annotations
+
+		input.Name = keyNode.Value
+		input.Description, descriptionAnnotations = parseExplicitAnnotations(input.Description)
+		input.Comment, commentAnnotations = parseExplicitAnnotations(formatKeyComments(keyNode, inputNode))
+		input.Annotations = mergeAnnotations(descriptionAnnotations, commentAnnotations)
 		inputs = append(inputs, input)
 	}
 
 	return inputs
 }
 
-// isJobMappingNode checks if a given YAML node represents a job mapping node.
+// formatKeyComments joins the head and line comment of a mapping key and the line comment
+// of its value as plain text.
 //
 // Parameters:
-//   - key: The key of the YAML node.
-//   - valueNode: The value node of the YAML node.
+//   - keyNode: The key node.
+//   - valueNode: The value node of the key.
 //
 // Returns:
-//   - bool: True if the node is a job mapping node, false otherwise.
-func isJobMappingNode(key string, valueNode yaml.Node) bool {
-	nonJobTopLevelKeywords := []string{
-		"stages",
-		"default",
-		"include",
-		"workflow",
-		"spec",
-		"image",
-		"services",
-		"cache",
-		"before_script",
-		"after_script",
-	}
-
-	if valueNode.Kind != yaml.MappingNode {
-		return false
-	}
+//   - string: The plain text comments, separated by newlines.
+func formatKeyComments(keyNode *yaml.Node, valueNode *yaml.Node) string {
+	comments := []string{}
 
-	if slices.Contains(nonJobTopLevelKeywords, key) {
-		return false
+	for _, comment := range []string{keyNode.HeadComment, keyNode.LineComment, valueNode.LineComment} {
+		if comment != "" {
+			comments = append(comments, yamlutils.FormatCommentAsPlainText(comment))
+		}
 	}
 
-	return true
+	return strings.Join(comments, "\n")
 }
 
 // parseYamlFileWithoutSeparatorsToGitLabCiConfig parses a YAML file content into a CiConfig,
-// removing YAML document separators.
+// removing YAML document separators. Local includes are not followed.
 //
 // Parameters:
 //   - yamlContent: The content of the YAML file.
@@ -149,16 +564,7 @@ func isJobMappingNode(key string, valueNode yaml.Node) bool {
 // Returns:
 //   - CiConfig: The parsed CiConfig struct.
 func parseYamlFileWithoutSeparatorsToGitLabCiConfig(yamlContent []byte) CiConfig {
-	yamlContentWithoutSeparators := yamlutils.RemoveYamlDocumentSeparators(yamlContent)
-
-	var gitlabCiConfig CiConfig
-
-	err := yaml.Unmarshal(yamlContentWithoutSeparators, &gitlabCiConfig)
-	if err != nil {
-		log.Fatalf("failed to parse YAML: %v", err)
-	}
-
-	return gitlabCiConfig
+	return parseComponentFile(nil, "", yamlContent)
 }
 
 // newComponentFromGitLabCiConfig creates a new Component from a CiConfig and a component name.
@@ -171,12 +577,41 @@ func parseYamlFileWithoutSeparatorsToGitLabCiConfig(yamlContent []byte) CiConfig
 //   - Component: The constructed Component struct.
 func newComponentFromGitLabCiConfig(gitlabCiConfig CiConfig, componentName string) Component {
 	component := Component{
-		Jobs:        gitlabCiConfig.Jobs,
-		Inputs:      gitlabCiConfig.Spec.Inputs,
-		Description: gitlabCiConfig.Spec.Comment,
-		Name:        componentName,
+		Jobs:                gitlabCiConfig.Jobs,
+		Inputs:              gitlabCiConfig.Spec.Inputs,
+		Description:         gitlabCiConfig.Spec.Comment,
+		Annotations:         gitlabCiConfig.Spec.Annotations,
+		Name:                componentName,
+		HiddenJobs:          gitlabCiConfig.HiddenJobs,
+		Stages:              gitlabCiConfig.Stages,
+		Variables:           gitlabCiConfig.Variables,
+		Default:             gitlabCiConfig.Default,
+		Workflow:            gitlabCiConfig.Workflow,
+		WorkflowRules:       gitlabCiConfig.WorkflowRules,
+		WorkflowSummary:     summarizeWorkflowRules(gitlabCiConfig.WorkflowRules),
+		DeprecatedKeywords:  gitlabCiConfig.DeprecatedKeywords,
+		Images:              slices.Clone(gitlabCiConfig.Images),
+		IncludedComponents:  includedComponents(gitlabCiConfig.Includes),
+		InputInterpolations: gitlabCiConfig.InputInterpolations,
 	}
 
+	for _, jobs := range [][]Job{component.Jobs, component.HiddenJobs} {
+		for index := range jobs {
+			jobs[index].ResolvedName, jobs[index].NameInputs = resolveInputDefaults(jobs[index].Name, component.Inputs)
+
+			for childIndex, child := range jobs[index].ExtendedBy {
+				jobs[index].ExtendedBy[childIndex], _ = resolveInputDefaults(child, component.Inputs)
+			}
+		}
+	}
+
+	for _, job := range slices.Concat(component.Jobs, component.HiddenJobs) {
+		resolveContainerImages(job.Images, component.Inputs)
+		component.Images = append(component.Images, job.Images...)
+	}
+
+	resolveContainerImages(component.Images, component.Inputs)
+
 	return component
 }
 
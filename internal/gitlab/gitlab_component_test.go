@@ -104,12 +104,14 @@ second-job: {}
 		},
 		Jobs: []Job{
 			{
-				Name:    "first-job",
-				Comment: "First job comment",
+				Name:            "first-job",
+				Comment:         "First job comment",
+				EffectiveConfig: "{}\n",
 			},
 			{
-				Name:    "second-job",
-				Comment: "Second job comment",
+				Name:            "second-job",
+				Comment:         "Second job comment",
+				EffectiveConfig: "{}\n",
 			},
 		},
 	}
@@ -184,19 +186,122 @@ func TestNewComponentFromGitLabCiConfigCreatesValidGitLabCiConfig(t *testing.T)
 	assert.Equal(t, expectedComponent, actualComponent)
 }
 
-func TestIsJobMappingNodeReturnsFalseOnNonJobTopLevelKeyword(t *testing.T) {
+func TestNewComponentFromGitLabCiConfigResolvesJobNames(t *testing.T) {
 	t.Parallel()
 
-	assert.False(t, isJobMappingNode("image", yaml.Node{}))
-	assert.False(t, isJobMappingNode("workflow", yaml.Node{}))
-	assert.False(t, isJobMappingNode("spec", yaml.Node{}))
+	gitlabCiConfig := CiConfig{
+		Spec: Spec{Inputs: []Input{{Name: "job-prefix", Default: "docs"}}},
+		Jobs: []Job{
+			{Name: "$[[ inputs.job-prefix ]]-build"},
+			{Name: "test"},
+		},
+		HiddenJobs: []Job{{
+			Name:       ".$[[ inputs.job-prefix ]]-base",
+			ExtendedBy: []string{"$[[ inputs.job-prefix ]]-build"},
+		}},
+	}
+
+	component := newComponentFromGitLabCiConfig(gitlabCiConfig, "component")
+
+	assert.Equal(t, "$[[ inputs.job-prefix ]]-build", component.Jobs[0].Name)
+	assert.Equal(t, "docs-build", component.Jobs[0].ResolvedName)
+	assert.Equal(t, []string{"job-prefix"}, component.Jobs[0].NameInputs)
+	assert.Equal(t, "test", component.Jobs[1].ResolvedName)
+	assert.Nil(t, component.Jobs[1].NameInputs)
+	assert.Equal(t, ".docs-base", component.HiddenJobs[0].ResolvedName)
+	assert.Equal(t, []string{"docs-build"}, component.HiddenJobs[0].ExtendedBy)
+}
+
+func TestUnmarshalYAMLClassifiesTopLevelKeywords(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `---
+# Spec comment
+spec:
+  inputs:
+    stage:
+variables:
+  SHORT: "value"
+  LONG:
+    value: "long value"
+    description: "Long description"
+    options: ["long value", "other"]
+  # Commented variable
+  COMMENTED: "commented"
+default:
+  interruptible: true
+workflow:
+  name: "Pipeline"
+image: "alpine"
+include:
+  - local: "other.yml"
+# Hidden job comment
+.hidden-job:
+  script: "echo hidden"
+pages:
+  script: "echo pages"
+`
+
+	expectedVariables := []Variable{
+		{Name: "SHORT", Value: "value"},
+		{Name: "LONG", Value: "long value", Description: "Long description", Options: []string{"long value", "other"}},
+		{Name: "COMMENTED", Value: "commented", Comment: "Commented variable"},
+	}
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+	assert.Equal(t, []Job{{
+		Name:            "pages",
+		EffectiveConfig: "script: \"echo pages\"\n",
+		Scripts:         []JobScript{{Keyword: "script", Lines: []string{"echo pages"}}},
+	}}, gitlabCiConfig.Jobs)
+	assert.Equal(t, []Job{{
+		Name:            ".hidden-job",
+		Comment:         "Hidden job comment",
+		Hidden:          true,
+		EffectiveConfig: "script: \"echo hidden\"\n",
+		Scripts:         []JobScript{{Keyword: "script", Lines: []string{"echo hidden"}}},
+	}}, gitlabCiConfig.HiddenJobs)
+	assert.Equal(t, expectedVariables, gitlabCiConfig.Variables)
+	assert.Equal(t, "interruptible: true\n", gitlabCiConfig.Default)
+	assert.Equal(t, "name: \"Pipeline\"\n", gitlabCiConfig.Workflow)
+	assert.Equal(t, []string{"image"}, gitlabCiConfig.DeprecatedKeywords)
+}
+
+func TestClassifyTopLevelKeyRecognizesGlobalKeywords(t *testing.T) {
+	t.Parallel()
+
+	mappingNode := yaml.Node{Kind: yaml.MappingNode}
+
+	for _, keyword := range []string{"spec", "default", "include", "stages", "variables", "workflow", "image"} {
+		assert.Equal(t, topLevelKeyKindKeyword, classifyTopLevelKey(keyword, mappingNode))
+	}
 }
 
-func TestIsJobMappingNodeReturnsFalseOnNonMappingNode(t *testing.T) {
+func TestClassifyTopLevelKeySeparatesHiddenJobs(t *testing.T) {
+	t.Parallel()
+
+	mappingNode := yaml.Node{Kind: yaml.MappingNode}
+
+	assert.Equal(t, topLevelKeyKindJob, classifyTopLevelKey("job", mappingNode))
+	assert.Equal(t, topLevelKeyKindJob, classifyTopLevelKey("pages", mappingNode))
+	assert.Equal(t, topLevelKeyKindHiddenJob, classifyTopLevelKey(".job", mappingNode))
+}
+
+func TestClassifyTopLevelKeyReturnsInvalidOnNonMappingNode(t *testing.T) {
 	t.Parallel()
 
 	valueNode := yaml.Node{Kind: yaml.ScalarNode}
-	assert.False(t, isJobMappingNode("job", valueNode))
+	assert.Equal(t, topLevelKeyKindInvalid, classifyTopLevelKey("job", valueNode))
+}
+
+func TestClassifyTopLevelKeyRecognizesHiddenAnchors(t *testing.T) {
+	t.Parallel()
+
+	sequenceNode := yaml.Node{Kind: yaml.SequenceNode}
+	assert.Equal(t, topLevelKeyKindAnchor, classifyTopLevelKey(".scripts", sequenceNode))
 }
 
 func TestGenerateComponentNameFromFilePathResultsInCorrectName(t *testing.T) {
@@ -209,3 +314,221 @@ func TestGenerateComponentNameFromFilePathResultsInCorrectName(t *testing.T) {
 	actualName = generateComponentNameFromFilePath("file.yml")
 	assert.Equal(t, expectedName, actualName)
 }
+
+func TestUnmarshalYAMLParsesStagesNeedsAndDependencies(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `---
+stages:
+  - "build"
+  - "test"
+build-job:
+  stage: "build"
+test-job:
+  stage: "test"
+  needs:
+    - "build-job"
+    - job: "other-job"
+      artifacts: false
+    - pipeline: "$PARENT_PIPELINE_ID"
+      job: "generate"
+  dependencies:
+    - "build-job"
+dynamic-job:
+  needs: "$[[ inputs.needs ]]"
+referencing-job:
+  needs: !reference [.other, needs]
+  dependencies: !reference [.other, dependencies]
+partially-referencing-job:
+  needs:
+    - "build-job"
+    - !reference [.other, needs]
+`
+
+	expectedJobs := []Job{
+		{Name: "build-job", Stage: "build"},
+		{
+			Name:         "test-job",
+			Stage:        "test",
+			Needs:        []string{"build-job", "other-job"},
+			Dependencies: []string{"build-job"},
+		},
+		{Name: "dynamic-job"},
+		{Name: "referencing-job"},
+		{Name: "partially-referencing-job", Needs: []string{"build-job"}},
+	}
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"build", "test"}, gitlabCiConfig.Stages)
+
+	for index, expectedJob := range expectedJobs {
+		actualJob := gitlabCiConfig.Jobs[index]
+		assert.Equal(t, expectedJob.Name, actualJob.Name)
+		assert.Equal(t, expectedJob.Stage, actualJob.Stage)
+		assert.Equal(t, expectedJob.Needs, actualJob.Needs)
+		assert.Equal(t, expectedJob.Dependencies, actualJob.Dependencies)
+	}
+}
+
+func TestUnmarshalYAMLResolvesExtendsAndReferences(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `---
+# Base job comment
+.base:
+  image: "alpine"
+  variables:
+    FIRST: "base"
+    SECOND: "base"
+.setup:
+  script:
+    - "echo setup"
+.other:
+  stage: "build"
+  variables:
+    SECOND: "other"
+job:
+  extends:
+    - ".base"
+    - ".other"
+  script:
+    - !reference [".setup", "script"]
+    - "echo job"
+external-job:
+  extends: "$[[ inputs.extends ]]"
+`
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+	require.Len(t, gitlabCiConfig.Jobs, 2)
+
+	job := gitlabCiConfig.Jobs[0]
+	assert.Equal(t, "build", job.Stage)
+	assert.Equal(t, []string{".base", ".other"}, job.Extends)
+	assert.Equal(t, []string{".base", ".other"}, job.ExtendsChain)
+	assert.True(t, job.UsesReferences)
+	assert.Equal(t, `image: "alpine"
+variables:
+  FIRST: "base"
+  SECOND: "other"
+stage: "build"
+script:
+  - "echo setup"
+  - "echo job"
+`, job.EffectiveConfig)
+
+	externalJob := gitlabCiConfig.Jobs[1]
+	assert.Equal(t, []string{"$[[ inputs.extends ]]"}, externalJob.Extends)
+	assert.Nil(t, externalJob.ExtendsChain)
+
+	require.Len(t, gitlabCiConfig.HiddenJobs, 3)
+	assert.True(t, gitlabCiConfig.HiddenJobs[0].Hidden)
+	assert.Equal(t, []string{"job"}, gitlabCiConfig.HiddenJobs[0].ExtendedBy)
+	assert.Nil(t, gitlabCiConfig.HiddenJobs[1].ExtendedBy)
+}
+
+func TestUnmarshalYAMLResolvesAnchorsAndMergeKeys(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `---
+spec:
+  inputs:
+    first-input: &input-defaults
+      type: "string"
+      default: "value"
+    second-input:
+      <<: *input-defaults
+      description: "Second input"
+.scripts: &scripts
+  - "echo setup"
+.job-defaults: &job-defaults
+  stage: "build"
+  needs: ["setup-job"]
+.template: &template
+  image: "alpine"
+job:
+  <<: *job-defaults
+  script: *scripts
+other-job:
+  extends: ".template"
+  <<: *template
+`
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []Input{
+		{Name: "first-input", Type: "string", Default: "value"},
+		{Name: "second-input", Type: "string", Default: "value", Description: "Second input"},
+	}, gitlabCiConfig.Spec.Inputs)
+
+	require.Len(t, gitlabCiConfig.Jobs, 2)
+	assert.Equal(t, "build", gitlabCiConfig.Jobs[0].Stage)
+	assert.Equal(t, []string{"setup-job"}, gitlabCiConfig.Jobs[0].Needs)
+	assert.Equal(t, "stage: \"build\"\nneeds: [\"setup-job\"]\nscript:\n  - \"echo setup\"\n",
+		gitlabCiConfig.Jobs[0].EffectiveConfig)
+
+	require.Len(t, gitlabCiConfig.HiddenJobs, 1)
+	assert.Equal(t, ".template", gitlabCiConfig.HiddenJobs[0].Name)
+}
+
+func TestParseSpecInputsReadsHeadAndLineComments(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `---
+spec:
+  inputs:
+    # The stage of the job.
+    # @since 1.2.0
+    stage: # Must exist in the pipeline.
+      default: "test"
+    image: {default: "alpine"} # The image of the job.
+    plain:
+      description: "Plain input"
+`
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+
+	inputs := gitlabCiConfig.Spec.Inputs
+	require.Len(t, inputs, 3)
+	assert.Equal(t, "The stage of the job.\nMust exist in the pipeline.", inputs[0].Comment)
+	assert.Equal(t, "1.2.0", inputs[0].Annotations.Since)
+	assert.Equal(t, "The image of the job.", inputs[1].Comment)
+	assert.Empty(t, inputs[2].Comment)
+	assert.Equal(t, "Plain input", inputs[2].Description)
+}
+
+func TestUnmarshalYAMLParsesJobVariablesOfEffectiveConfiguration(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `.base:
+  variables:
+    BASE: "base"
+job:
+  extends: ".base"
+  variables:
+    # The environment
+    ENVIRONMENT:
+      value: "prod"
+      options: ["prod", "staging"]
+`
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+	assert.Equal(t, []Variable{
+		{Name: "BASE", Value: "base"},
+		{Name: "ENVIRONMENT", Value: "prod", Options: []string{"prod", "staging"}, Comment: "The environment"},
+	}, gitlabCiConfig.Jobs[0].Variables)
+}
@@ -0,0 +1,275 @@
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/erNail/labdoc/internal/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+const (
+	// goldenComponentDirectory is the directory of a test case that contains the component fixtures.
+	goldenComponentDirectory = "components"
+	// goldenExpectedFile is the file of a test case that contains the expected documentation.
+	goldenExpectedFile = "expected.md"
+	// goldenDiffContextLines is the number of unchanged lines shown around a change.
+	goldenDiffContextLines = 2
+)
+
+// TemplateTester defines the interface for testing templates against golden files.
+type TemplateTester interface {
+	TestTemplate(
+		filesystem afero.Fs,
+		writer io.Writer,
+		testDirectory string,
+		templateFilePath string,
+		repoURL string,
+		componentVersion string,
+		update bool)
+}
+
+// RealTemplateTester implements the TemplateTester interface.
+type RealTemplateTester struct{}
+
+// TestTemplate discovers the test cases in the test directory, renders the component fixtures
+// of each test case with the template, and compares the result with the expected documentation.
+// Each test case is a directory containing a "components" directory and an "expected.md" file.
+// A test case may contain its own ".labdoc.yml" configuration file.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - writer: The writer to which the test report is written.
+//   - testDirectory: The directory containing the test cases.
+//   - templateFilePath: The path to the template file under test.
+//   - repoURL: The repository URL used when rendering the test cases.
+//   - componentVersion: The version used when rendering the test cases.
+//   - update: If true, the expected documentation is overwritten with the rendered documentation.
+func (r *RealTemplateTester) TestTemplate(
+	filesystem afero.Fs,
+	writer io.Writer,
+	testDirectory string,
+	templateFilePath string,
+	repoURL string,
+	componentVersion string,
+	update bool,
+) {
+	testCases := findGoldenTestCases(filesystem, testDirectory)
+	if len(testCases) == 0 {
+		log.WithField("testDir", testDirectory).Fatal("No test cases found in directory")
+	}
+
+	failedTestCases := 0
+
+	for _, testCase := range testCases {
+		testCaseDirectory := filepath.Join(testDirectory, testCase)
+		expectedFilePath := filepath.Join(testCaseDirectory, goldenExpectedFile)
+
+		configFilePath := filepath.Join(testCaseDirectory, config.DefaultConfigFilePath)
+
+		configuration, err := config.LoadConfig(filesystem, configFilePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		componentsDocumentation := buildComponentDocumentationFromDirectory(
+			filesystem,
+			filepath.Join(testCaseDirectory, goldenComponentDirectory),
+			repoURL,
+			componentVersion,
+			configuration,
+		)
+		actualContent := renderDocumentationContent(componentsDocumentation, templateFilePath, filesystem, false)
+
+		if update {
+			writeDocumentation(filesystem, expectedFilePath, actualContent)
+			writeReportLine(writer, "updated %s", testCase)
+
+			continue
+		}
+
+		expectedContent, err := afero.ReadFile(filesystem, expectedFilePath)
+		if err != nil {
+			log.WithField("testCase", testCase).Error(err)
+
+			failedTestCases++
+
+			writeReportLine(writer, "FAIL %s", testCase)
+
+			continue
+		}
+
+		if string(expectedContent) == actualContent {
+			writeReportLine(writer, "ok   %s", testCase)
+
+			continue
+		}
+
+		failedTestCases++
+
+		writeReportLine(writer, "FAIL %s", testCase)
+		writeReportLine(writer, "%s", diffLines(string(expectedContent), actualContent))
+	}
+
+	if failedTestCases > 0 {
+		log.WithField("failedTestCases", failedTestCases).Fatal("Template tests failed")
+	}
+
+	log.WithField("testCaseCount", len(testCases)).Info("All template tests passed!")
+}
+
+// findGoldenTestCases returns the sorted names of all test cases in the test directory.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - testDirectory: The directory containing the test cases.
+//
+// Returns:
+//   - []string: The names of the test case directories.
+func findGoldenTestCases(filesystem afero.Fs, testDirectory string) []string {
+	entries, err := afero.ReadDir(filesystem, testDirectory)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	testCases := []string{}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		componentDirectoryExists, err := afero.DirExists(
+			filesystem,
+			filepath.Join(testDirectory, entry.Name(), goldenComponentDirectory),
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if componentDirectoryExists {
+			testCases = append(testCases, entry.Name())
+		}
+	}
+
+	slices.Sort(testCases)
+
+	return testCases
+}
+
+// writeReportLine writes a single formatted line to the test report.
+//
+// Parameters:
+//   - writer: The writer to which the test report is written.
+//   - format: The format of the line.
+//   - args: The arguments for the format.
+func writeReportLine(writer io.Writer, format string, args ...interface{}) {
+	_, err := fmt.Fprintf(writer, format+"\n", args...)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// diffLines creates a line-based diff between the expected and actual content.
+// Removed lines are prefixed with "-", added lines with "+", and unchanged
+// lines around a change are prefixed with a space.
+//
+// Parameters:
+//   - expected: The expected content.
+//   - actual: The actual content.
+//
+// Returns:
+//   - string: The diff between both contents.
+func diffLines(expected string, actual string) string {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+
+	// commonLengths[i][j] is the length of the longest common subsequence
+	// of expectedLines[i:] and actualLines[j:].
+	commonLengths := make([][]int, len(expectedLines)+1)
+	for i := range commonLengths {
+		commonLengths[i] = make([]int, len(actualLines)+1)
+	}
+
+	for i := len(expectedLines) - 1; i >= 0; i-- {
+		for j := len(actualLines) - 1; j >= 0; j-- {
+			if expectedLines[i] == actualLines[j] {
+				commonLengths[i][j] = commonLengths[i+1][j+1] + 1
+			} else {
+				commonLengths[i][j] = max(commonLengths[i+1][j], commonLengths[i][j+1])
+			}
+		}
+	}
+
+	diff := []string{}
+	i, j := 0, 0
+
+	for i < len(expectedLines) || j < len(actualLines) {
+		switch {
+		case i < len(expectedLines) && j < len(actualLines) && expectedLines[i] == actualLines[j]:
+			diff = append(diff, " "+expectedLines[i])
+			i++
+			j++
+		case j < len(actualLines) && (i == len(expectedLines) || commonLengths[i][j+1] > commonLengths[i+1][j]):
+			diff = append(diff, "+"+actualLines[j])
+			j++
+		default:
+			diff = append(diff, "-"+expectedLines[i])
+			i++
+		}
+	}
+
+	return strings.Join(trimUnchangedDiffLines(diff), "\n")
+}
+
+// trimUnchangedDiffLines removes unchanged lines that are not close to a change.
+//
+// Parameters:
+//   - diff: The diff lines.
+//
+// Returns:
+//   - []string: The diff lines close to a change.
+func trimUnchangedDiffLines(diff []string) []string {
+	keep := make([]bool, len(diff))
+
+	for index, line := range diff {
+		if strings.HasPrefix(line, " ") {
+			continue
+		}
+
+		firstContextIndex := max(index-goldenDiffContextLines, 0)
+		lastContextIndex := min(index+goldenDiffContextLines, len(diff)-1)
+
+		for contextIndex := firstContextIndex; contextIndex <= lastContextIndex; contextIndex++ {
+			keep[contextIndex] = true
+		}
+	}
+
+	trimmedDiff := []string{}
+	skipped := false
+
+	for index, line := range diff {
+		if !keep[index] {
+			skipped = true
+
+			continue
+		}
+
+		if skipped {
+			trimmedDiff = append(trimmedDiff, "...")
+			skipped = false
+		}
+
+		trimmedDiff = append(trimmedDiff, line)
+	}
+
+	if skipped {
+		trimmedDiff = append(trimmedDiff, "...")
+	}
+
+	return trimmedDiff
+}
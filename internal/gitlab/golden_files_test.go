@@ -0,0 +1,91 @@
+package gitlab
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createGoldenTestCase(t *testing.T, filesystem afero.Fs, testCase string, expectedContent string) {
+	t.Helper()
+
+	componentContent := `---
+# Component description
+spec:
+  inputs:
+    stage:
+...
+`
+
+	err := afero.WriteFile(filesystem, "tests/"+testCase+"/components/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+	err = afero.WriteFile(filesystem, "tests/"+testCase+"/expected.md", []byte(expectedContent), 0o644)
+	require.NoError(t, err)
+}
+
+func TestTestTemplatePassesMatchingTestCases(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	createGoldenTestCase(t, filesystem, "first", "component: Component description")
+	createGoldenTestCase(t, filesystem, "second", "component: Component description")
+	err := afero.WriteFile(
+		filesystem,
+		"template.md.gotmpl",
+		[]byte(`{{ range .Components }}{{ .Name }}: {{ .Description }}{{ end }}`),
+		0o644,
+	)
+	require.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	templateTester := &RealTemplateTester{}
+	templateTester.TestTemplate(filesystem, buffer, "tests", "template.md.gotmpl", "github.com/test", "1.0.0", false)
+
+	assert.Equal(t, "ok   first\nok   second\n", buffer.String())
+}
+
+func TestTestTemplateUpdatesExpectedFiles(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	createGoldenTestCase(t, filesystem, "first", "outdated")
+	err := afero.WriteFile(filesystem, "template.md.gotmpl", []byte(`{{ .Version }}`), 0o644)
+	require.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	templateTester := &RealTemplateTester{}
+	templateTester.TestTemplate(filesystem, buffer, "tests", "template.md.gotmpl", "github.com/test", "1.0.0", true)
+
+	expectedContent, err := afero.ReadFile(filesystem, "tests/first/expected.md")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", string(expectedContent))
+	assert.Equal(t, "updated first\n", buffer.String())
+}
+
+func TestFindGoldenTestCasesIgnoresDirectoriesWithoutComponents(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	createGoldenTestCase(t, filesystem, "b-case", "")
+	createGoldenTestCase(t, filesystem, "a-case", "")
+	err := filesystem.MkdirAll("tests/not-a-case", 0o755)
+	require.NoError(t, err)
+
+	testCases := findGoldenTestCases(filesystem, "tests")
+
+	assert.Equal(t, []string{"a-case", "b-case"}, testCases)
+}
+
+func TestDiffLinesShowsChangedLinesWithContext(t *testing.T) {
+	t.Parallel()
+
+	expected := "one\ntwo\nthree\nfour\nfive\nsix\nseven"
+	actual := "one\ntwo\nthree\nfour\nFIVE\nsix\nseven"
+
+	expectedDiff := "...\n three\n four\n-five\n+FIVE\n six\n seven"
+
+	assert.Equal(t, expectedDiff, diffLines(expected, actual))
+}
@@ -0,0 +1,362 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ImageKindImage is the kind of images set via the `image` keyword.
+	ImageKindImage = "image"
+	// ImageKindService is the kind of images set via the `services` keyword.
+	ImageKindService = "service"
+)
+
+// ContainerImage is a container image used via the `image` or `services` keyword.
+type ContainerImage struct {
+	// Reference is the image as written in the component, e.g. "alpine:$[[ inputs.version ]]".
+	Reference string
+	// ResolvedReference is the reference in which interpolated inputs are replaced by their defaults.
+	// Interpolations of inputs without a default are kept.
+	ResolvedReference string
+	// Kind is either "image" or "service".
+	Kind string
+	// Job is the job using the image. Empty for images of the `default` keyword and global keywords.
+	Job string
+	// Inputs are the names of the inputs interpolated in the reference.
+	Inputs []string
+	// Name is the resolved reference without tag and digest, e.g. "registry.example.com/alpine".
+	Name string
+	// Tag is the tag of the resolved reference, e.g. "3.20". Empty if the reference has no tag.
+	Tag string
+	// Digest is the digest of the resolved reference, e.g. "sha256:abc". Empty if the reference has no digest.
+	Digest string
+}
+
+// IsPinned checks if the image is pinned by a digest or by a tag other than "latest".
+//
+// Returns:
+//   - bool: True if the image is pinned.
+func (image ContainerImage) IsPinned() bool {
+	return image.Digest != "" || (image.Tag != "" && image.Tag != "latest")
+}
+
+// IsResolved checks if the reference of the image is known without running a pipeline, i.e. if it
+// contains neither interpolations of inputs without a default nor CI/CD variables.
+//
+// Returns:
+//   - bool: True if the resolved reference contains no interpolations and no variables.
+func (image ContainerImage) IsResolved() bool {
+	return !strings.Contains(image.ResolvedReference, "$")
+}
+
+// findContainerImages finds the images of the `image` and `services` keywords of a mapping,
+// like a job or the `default` keyword.
+//
+// Parameters:
+//   - mappingNode: The mapping node containing the keywords.
+//   - job: The name of the job. Empty for the `default` keyword.
+//
+// Returns:
+//   - []ContainerImage: The images, with the image before the services.
+func findContainerImages(mappingNode *yaml.Node, job string) []ContainerImage {
+	var images []ContainerImage
+
+	if imageNode := mappingValue(mappingNode, "image"); imageNode != nil {
+		images = append(images, newContainerImages([]*yaml.Node{imageNode}, ImageKindImage, job)...)
+	}
+
+	if servicesNode := mappingValue(mappingNode, "services"); servicesNode != nil &&
+		servicesNode.Kind == yaml.SequenceNode {
+		images = append(images, newContainerImages(servicesNode.Content, ImageKindService, job)...)
+	}
+
+	return images
+}
+
+// newContainerImages creates images from the values of an `image` or `services` keyword,
+// which are either a reference or a mapping with the reference as `name`.
+//
+// Parameters:
+//   - imageNodes: The nodes of the images.
+//   - kind: The kind of the images, either "image" or "service".
+//   - job: The name of the job using the images.
+//
+// Returns:
+//   - []ContainerImage: The images. Nodes without a reference are skipped.
+func newContainerImages(imageNodes []*yaml.Node, kind string, job string) []ContainerImage {
+	images := []ContainerImage{}
+
+	for _, imageNode := range imageNodes {
+		if imageNode.Kind == yaml.MappingNode {
+			imageNode = mappingValue(imageNode, "name")
+		}
+
+		if imageNode == nil || imageNode.Kind != yaml.ScalarNode || imageNode.Value == "" {
+			continue
+		}
+
+		images = append(images, ContainerImage{Reference: imageNode.Value, Kind: kind, Job: job})
+	}
+
+	return images
+}
+
+// resolveContainerImages replaces the interpolated inputs of each image by their defaults
+// and splits the resolved references into name, tag and digest.
+//
+// Parameters:
+//   - images: The images to resolve. They are modified in place.
+//   - inputs: The inputs of the component.
+func resolveContainerImages(images []ContainerImage, inputs []Input) {
+	for index := range images {
+		image := &images[index]
+		image.ResolvedReference, image.Inputs = resolveInputDefaults(image.Reference, inputs)
+
+		image.Name, image.Tag, image.Digest = "", "", ""
+		if image.IsResolved() {
+			image.Name, image.Tag, image.Digest = splitImageReference(image.ResolvedReference)
+		}
+	}
+}
+
+// splitImageReference splits an image reference into name, tag and digest.
+//
+// Parameters:
+//   - reference: The image reference, e.g. "registry.example.com:5000/alpine:3.20@sha256:abc".
+//
+// Returns:
+//   - string: The name, e.g. "registry.example.com:5000/alpine".
+//   - string: The tag, e.g. "3.20". Empty if the reference has no tag.
+//   - string: The digest, e.g. "sha256:abc". Empty if the reference has no digest.
+func splitImageReference(reference string) (string, string, string) {
+	name, digest, _ := strings.Cut(reference, "@")
+
+	tagIndex := strings.LastIndex(name, ":")
+	if tagIndex == -1 || tagIndex < strings.LastIndex(name, "/") {
+		return name, "", digest
+	}
+
+	return name[:tagIndex], name[tagIndex+1:], digest
+}
+
+// checkUnpinnedImages reports images that are neither pinned by a tag nor by a digest.
+// Images whose reference depends on mandatory inputs or CI/CD variables are skipped.
+// Each reference is reported once per component.
+//
+// Parameters:
+//   - context: The data to check.
+//
+// Returns:
+//   - []LintIssue: An issue for every unpinned image.
+func checkUnpinnedImages(context lintContext) []LintIssue {
+	issues := []LintIssue{}
+
+	for _, component := range context.Components {
+		reportedReferences := []string{}
+
+		for _, image := range component.Images {
+			if !image.IsResolved() || image.IsPinned() || slices.Contains(reportedReferences, image.ResolvedReference) {
+				continue
+			}
+
+			reportedReferences = append(reportedReferences, image.ResolvedReference)
+
+			message := fmt.Sprintf("%s %q is not pinned by a tag or digest", image.Kind, image.ResolvedReference)
+			if image.Job != "" {
+				message += fmt.Sprintf(" in job %q", image.Job)
+			}
+
+			if len(image.Inputs) > 0 {
+				message += fmt.Sprintf(" (default of input %q)", image.Inputs[0])
+			}
+
+			issues = append(issues, LintIssue{Component: component.Name, Message: message})
+		}
+	}
+
+	return issues
+}
+
+// ImageInventoryExporter defines the interface for exporting the container images of components.
+type ImageInventoryExporter interface {
+	ExportImageInventory(filesystem afero.Fs, writer io.Writer, componentDirectory string, format string)
+}
+
+// RealImageInventoryExporter implements the ImageInventoryExporter interface.
+type RealImageInventoryExporter struct{}
+
+// componentImages are the container images of a single component in the JSON inventory.
+type componentImages struct {
+	Component string
+	Images    []ContainerImage
+}
+
+// cycloneDxBom is a CycloneDX bill of materials listing container images.
+type cycloneDxBom struct {
+	BomFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Components  []cycloneDxComponent `json:"components"`
+}
+
+// cycloneDxComponent is a container image of a CycloneDX bill of materials.
+type cycloneDxComponent struct {
+	Type       string              `json:"type"`
+	BomRef     string              `json:"bom-ref"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	Hashes     []cycloneDxHash     `json:"hashes,omitempty"`
+	Properties []cycloneDxProperty `json:"properties"`
+}
+
+// cycloneDxHash is the digest of a container image.
+type cycloneDxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+// cycloneDxProperty is a name-value pair describing where a container image is used.
+type cycloneDxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ExportImageInventory writes all container images of the components in the directory.
+// Components and elements hidden from the documentation are included.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - writer: The writer to which the inventory is written.
+//   - componentDirectory: The directory containing the component YAML files.
+//   - format: The format of the inventory. Either "json" or "cyclonedx".
+func (r *RealImageInventoryExporter) ExportImageInventory(
+	filesystem afero.Fs,
+	writer io.Writer,
+	componentDirectory string,
+	format string,
+) {
+	components := sortComponents(readComponentsFromDirectory(filesystem, componentDirectory))
+
+	data, err := marshalImageInventory(components, format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = writer.Write(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// marshalImageInventory serializes the container images of the components.
+//
+// Parameters:
+//   - components: The components, sorted by name.
+//   - format: The format of the inventory. Either "json" or "cyclonedx".
+//
+// Returns:
+//   - []byte: The serialized inventory.
+//   - error: An error if the format is unknown or serialization fails.
+func marshalImageInventory(components []Component, format string) ([]byte, error) {
+	var inventory interface{}
+
+	switch format {
+	case "json":
+		inventoryEntries := []componentImages{}
+
+		for _, component := range components {
+			inventoryEntries = append(inventoryEntries, componentImages{
+				Component: component.Name,
+				Images:    component.Images,
+			})
+		}
+
+		inventory = inventoryEntries
+	case "cyclonedx":
+		inventory = buildCycloneDxBom(components)
+	default:
+		return nil, fmt.Errorf("unknown inventory format %q. use \"json\" or \"cyclonedx\"", format)
+	}
+
+	data, err := json.MarshalIndent(inventory, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize image inventory: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// buildCycloneDxBom creates a CycloneDX bill of materials with one component per resolved reference.
+// The components and jobs using an image are listed as `labdoc:usage` properties.
+//
+// Parameters:
+//   - components: The components, sorted by name.
+//
+// Returns:
+//   - cycloneDxBom: The bill of materials.
+func buildCycloneDxBom(components []Component) cycloneDxBom {
+	bom := cycloneDxBom{BomFormat: "CycloneDX", SpecVersion: "1.5", Version: 1, Components: []cycloneDxComponent{}}
+
+	for _, component := range components {
+		for _, image := range component.Images {
+			usage := cycloneDxProperty{Name: "labdoc:usage", Value: component.Name}
+			if image.Job != "" {
+				usage.Value += "/" + image.Job
+			}
+
+			bomIndex := slices.IndexFunc(bom.Components, func(bomComponent cycloneDxComponent) bool {
+				return bomComponent.BomRef == image.ResolvedReference
+			})
+			if bomIndex != -1 {
+				if !slices.Contains(bom.Components[bomIndex].Properties, usage) {
+					bom.Components[bomIndex].Properties = append(bom.Components[bomIndex].Properties, usage)
+				}
+
+				continue
+			}
+
+			bomComponent := cycloneDxComponent{
+				Type:       "container",
+				BomRef:     image.ResolvedReference,
+				Name:       image.ResolvedReference,
+				Properties: []cycloneDxProperty{usage},
+			}
+
+			if image.IsResolved() {
+				bomComponent.Name = image.Name
+				bomComponent.Version = image.Tag
+			}
+
+			if algorithm, content, hasDigest := strings.Cut(image.Digest, ":"); hasDigest {
+				bomComponent.Hashes = []cycloneDxHash{{Alg: cycloneDxHashAlgorithm(algorithm), Content: content}}
+			}
+
+			bom.Components = append(bom.Components, bomComponent)
+		}
+	}
+
+	return bom
+}
+
+// cycloneDxHashAlgorithm converts the algorithm of an image digest into the CycloneDX notation.
+//
+// Parameters:
+//   - algorithm: The algorithm of the digest, e.g. "sha256".
+//
+// Returns:
+//   - string: The CycloneDX algorithm, e.g. "SHA-256".
+func cycloneDxHashAlgorithm(algorithm string) string {
+	if number, isSha := strings.CutPrefix(algorithm, "sha"); isSha {
+		return "SHA-" + number
+	}
+
+	return strings.ToUpper(algorithm)
+}
@@ -0,0 +1,181 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitImageReferenceSplitsNameTagAndDigest(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		reference      string
+		expectedName   string
+		expectedTag    string
+		expectedDigest string
+	}{
+		{"alpine", "alpine", "", ""},
+		{"alpine:3.20", "alpine", "3.20", ""},
+		{"registry.example.com:5000/group/alpine", "registry.example.com:5000/group/alpine", "", ""},
+		{"registry.example.com:5000/alpine:3.20@sha256:abc", "registry.example.com:5000/alpine", "3.20", "sha256:abc"},
+		{"alpine@sha256:abc", "alpine", "", "sha256:abc"},
+	}
+
+	for _, testCase := range testCases {
+		name, tag, digest := splitImageReference(testCase.reference)
+		assert.Equal(t, testCase.expectedName, name, testCase.reference)
+		assert.Equal(t, testCase.expectedTag, tag, testCase.reference)
+		assert.Equal(t, testCase.expectedDigest, digest, testCase.reference)
+	}
+}
+
+func TestNewComponentCollectsAndResolvesImages(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := []byte(`spec:
+  inputs:
+    version:
+      default: 20
+    registry: {}
+---
+default:
+  image: alpine:3.20
+  services:
+    - name: postgres@sha256:abc
+.template:
+  services:
+    - $[[ inputs.registry ]]/redis
+job:
+  image:
+    name: node:$[[ inputs.version ]]
+`)
+
+	gitlabCiConfig := parseYamlFileWithoutSeparatorsToGitLabCiConfig(yamlContent)
+	component := newComponentFromGitLabCiConfig(gitlabCiConfig, "component")
+
+	expectedImages := []ContainerImage{
+		{Reference: "alpine:3.20", ResolvedReference: "alpine:3.20", Kind: ImageKindImage, Name: "alpine", Tag: "3.20"},
+		{
+			Reference:         "postgres@sha256:abc",
+			ResolvedReference: "postgres@sha256:abc",
+			Kind:              ImageKindService,
+			Name:              "postgres",
+			Digest:            "sha256:abc",
+		},
+		{
+			Reference:         "node:$[[ inputs.version ]]",
+			ResolvedReference: "node:20",
+			Kind:              ImageKindImage,
+			Job:               "job",
+			Inputs:            []string{"version"},
+			Name:              "node",
+			Tag:               "20",
+		},
+		{
+			Reference:         "$[[ inputs.registry ]]/redis",
+			ResolvedReference: "$[[ inputs.registry ]]/redis",
+			Kind:              ImageKindService,
+			Job:               ".template",
+			Inputs:            []string{"registry"},
+		},
+	}
+	assert.Equal(t, expectedImages, component.Images)
+	assert.Equal(t, expectedImages[2:3], component.Jobs[0].Images)
+}
+
+func TestCheckUnpinnedImagesReportsEachUnpinnedReferenceOnce(t *testing.T) {
+	t.Parallel()
+
+	images := []ContainerImage{
+		{Reference: "alpine", Kind: ImageKindImage, Job: "build"},
+		{Reference: "alpine", Kind: ImageKindImage, Job: "test"},
+		{Reference: "node:latest", Kind: ImageKindImage},
+		{Reference: "$[[ inputs.image ]]", Kind: ImageKindService, Job: "test"},
+		{Reference: "python:3.12", Kind: ImageKindImage, Job: "lint"},
+		{Reference: "$CI_REGISTRY_IMAGE", Kind: ImageKindImage, Job: "release"},
+	}
+	resolveContainerImages(images, []Input{{Name: "image", Default: "redis"}})
+
+	issues := checkUnpinnedImages(lintContext{Components: []Component{{Name: "component", Images: images}}})
+	assert.Equal(t, []LintIssue{
+		{Component: "component", Message: `image "alpine" is not pinned by a tag or digest in job "build"`},
+		{Component: "component", Message: `image "node:latest" is not pinned by a tag or digest`},
+		{
+			Component: "component",
+			Message:   `service "redis" is not pinned by a tag or digest in job "test" (default of input "image")`,
+		},
+	}, issues)
+}
+
+func TestMarshalImageInventoryWritesCycloneDxBom(t *testing.T) {
+	t.Parallel()
+
+	images := []ContainerImage{
+		{Reference: "alpine:3.20@sha256:abc", Kind: ImageKindImage, Job: "build"},
+		{Reference: "alpine:3.20@sha256:abc", Kind: ImageKindImage, Job: "test"},
+		{Reference: "$[[ inputs.image ]]", Kind: ImageKindImage},
+	}
+	resolveContainerImages(images, []Input{{Name: "image"}})
+
+	data, err := marshalImageInventory([]Component{{Name: "component", Images: images}}, "cyclonedx")
+	require.NoError(t, err)
+
+	var bom cycloneDxBom
+	require.NoError(t, json.Unmarshal(data, &bom))
+
+	assert.Equal(t, cycloneDxBom{
+		BomFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Components: []cycloneDxComponent{
+			{
+				Type:    "container",
+				BomRef:  "alpine:3.20@sha256:abc",
+				Name:    "alpine",
+				Version: "3.20",
+				Hashes:  []cycloneDxHash{{Alg: "SHA-256", Content: "abc"}},
+				Properties: []cycloneDxProperty{
+					{Name: "labdoc:usage", Value: "component/build"},
+					{Name: "labdoc:usage", Value: "component/test"},
+				},
+			},
+			{
+				Type:       "container",
+				BomRef:     "$[[ inputs.image ]]",
+				Name:       "$[[ inputs.image ]]",
+				Properties: []cycloneDxProperty{{Name: "labdoc:usage", Value: "component"}},
+			},
+		},
+	}, bom)
+}
+
+func TestMarshalImageInventoryFailsForUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := marshalImageInventory([]Component{}, "xml")
+	require.EqualError(t, err, `unknown inventory format "xml". use "json" or "cyclonedx"`)
+}
+
+func TestExportImageInventoryWritesJSON(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte("job:\n  image: alpine:3.20\n"), 0o644)
+	require.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	exporter := &RealImageInventoryExporter{}
+	exporter.ExportImageInventory(filesystem, buffer, "templates", "json")
+
+	var inventory []componentImages
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &inventory))
+	require.Len(t, inventory, 1)
+	assert.Equal(t, "component", inventory[0].Component)
+	assert.Equal(t, "alpine:3.20", inventory[0].Images[0].ResolvedReference)
+	assert.Equal(t, "job", inventory[0].Images[0].Job)
+}
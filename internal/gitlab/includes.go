@@ -0,0 +1,429 @@
+package gitlab
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/erNail/labdoc/internal/yamlutils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// IncludeKindLocal includes a file of the same repository.
+	IncludeKindLocal = "local"
+	// IncludeKindComponent includes a CI/CD component.
+	IncludeKindComponent = "component"
+	// IncludeKindProject includes a file of another project.
+	IncludeKindProject = "project"
+	// IncludeKindRemote includes a file from a URL.
+	IncludeKindRemote = "remote"
+	// IncludeKindTemplate includes a CI/CD template of GitLab.
+	IncludeKindTemplate = "template"
+)
+
+// includeKinds are all supported kinds of includes, in the order in which they are detected.
+var includeKinds = []string{
+	IncludeKindLocal,
+	IncludeKindComponent,
+	IncludeKindProject,
+	IncludeKindRemote,
+	IncludeKindTemplate,
+}
+
+// Include is an entry of the `include` keyword.
+type Include struct {
+	// Kind is the kind of the include, e.g. "local" or "component".
+	Kind string
+	// Location is the value of the include, e.g. "/templates/jobs.yml" or
+	// "$CI_SERVER_FQDN/group/project/build@1.0.0".
+	Location string
+}
+
+// parseIncludes parses the entries of an `include` keyword. The keyword is either a single
+// include or a list of includes. Each include is either a string or a mapping like `local: path`.
+// Strings are local includes, unless they are URLs.
+//
+// Parameters:
+//   - includeNode: The YAML node of the `include` keyword.
+//
+// Returns:
+//   - []Include: The includes, in the order of their definition. Entries of unknown kinds are skipped.
+func parseIncludes(includeNode yaml.Node) []Include {
+	var includes []Include
+
+	includeNodes := []*yaml.Node{&includeNode}
+	if includeNode.Kind == yaml.SequenceNode {
+		includeNodes = includeNode.Content
+	}
+
+	for _, node := range includeNodes {
+		switch node.Kind {
+		case yaml.ScalarNode:
+			kind := IncludeKindLocal
+			if strings.HasPrefix(node.Value, "http://") || strings.HasPrefix(node.Value, "https://") {
+				kind = IncludeKindRemote
+			}
+
+			includes = append(includes, Include{Kind: kind, Location: node.Value})
+		case yaml.MappingNode:
+			for _, kind := range includeKinds {
+				if locationNode := mappingValue(node, kind); locationNode != nil {
+					includes = append(includes, Include{Kind: kind, Location: locationNode.Value})
+
+					break
+				}
+			}
+		}
+	}
+
+	return includes
+}
+
+// componentFile is a file read for a component.
+type componentFile struct {
+	path string
+	// lineOffset is the number of lines of all files read before the file.
+	lineOffset int
+	lineCount  int
+}
+
+// componentLoader reads a component file together with its local includes. The lines of all files
+// are concatenated in the order in which the files are read, so that each YAML node has a unique line.
+type componentLoader struct {
+	// filesystem is used to read local includes. If nil, local includes are not followed.
+	filesystem afero.Fs
+	files      []componentFile
+	lines      []string
+	// sources are the paths of the included files that define a top-level key.
+	// Keys defined by the component file are not listed.
+	sources map[string]string
+	// includes are the includes of included files that are not local.
+	includes []Include
+}
+
+// parseComponentFile parses a component file into a CiConfig, merging the configuration of its local
+// includes the way GitLab does it. Paths are relative to the repository root, which is the working directory.
+// Included files are followed recursively. Top-level keys defined in several files are deep-merged,
+// with the including file and later includes taking precedence, so that `extends` and `!reference`
+// are resolved across files. Jobs of included files keep the path of their file as source.
+// All other includes of included files, like components, are added to the includes of the configuration.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system. If nil, local includes are not followed.
+//   - filePath: The path of the component file.
+//   - yamlContent: The content of the component file.
+//
+// Returns:
+//   - CiConfig: The parsed CiConfig struct.
+func parseComponentFile(filesystem afero.Fs, filePath string, yamlContent []byte) CiConfig {
+	loader := &componentLoader{filesystem: filesystem, sources: map[string]string{}}
+	mappingNode := loader.mergeLocalIncludes(loader.readFile(filePath, yamlContent), []string{filePath})
+
+	var gitlabCiConfig CiConfig
+
+	err := mappingNode.Decode(&gitlabCiConfig)
+	if err != nil {
+		log.Fatalf("failed to parse YAML: %v", err)
+	}
+
+	for _, include := range loader.includes {
+		if !slices.Contains(gitlabCiConfig.Includes, include) {
+			gitlabCiConfig.Includes = append(gitlabCiConfig.Includes, include)
+		}
+	}
+
+	lines := []byte(strings.Join(loader.lines, "\n"))
+
+	for _, jobs := range [][]Job{gitlabCiConfig.Jobs, gitlabCiConfig.HiddenJobs} {
+		for index := range jobs {
+			jobs[index].Source = loader.sources[jobs[index].Name]
+			locateInputInterpolations(jobs[index].InputInterpolations, lines)
+			loader.setInterpolationSources(jobs[index].InputInterpolations)
+		}
+	}
+
+	locateInputInterpolations(gitlabCiConfig.InputInterpolations, lines)
+	loader.setInterpolationSources(gitlabCiConfig.InputInterpolations)
+
+	return gitlabCiConfig
+}
+
+// readFile parses a file and appends its lines to the lines of all files.
+// The lines of the YAML nodes are shifted by the number of lines read before.
+//
+// Parameters:
+//   - filePath: The path of the file.
+//   - yamlContent: The content of the file.
+//
+// Returns:
+//   - *yaml.Node: The top-level mapping of the file. Empty if the file contains no mapping.
+func (loader *componentLoader) readFile(filePath string, yamlContent []byte) *yaml.Node {
+	yamlContentWithoutSeparators := yamlutils.RemoveYamlDocumentSeparators(yamlContent)
+
+	var documentNode yaml.Node
+
+	err := yaml.Unmarshal(yamlContentWithoutSeparators, &documentNode)
+	if err != nil {
+		log.WithField("filePath", filePath).Fatalf("failed to parse YAML: %v", err)
+	}
+
+	fileLines := strings.Split(string(yamlContentWithoutSeparators), "\n")
+	lineOffset := len(loader.lines)
+	loader.files = append(
+		loader.files, componentFile{path: filePath, lineOffset: lineOffset, lineCount: len(fileLines)},
+	)
+	loader.lines = append(loader.lines, fileLines...)
+
+	if len(documentNode.Content) == 0 || documentNode.Content[0].Kind != yaml.MappingNode {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	shiftNodeLines(documentNode.Content[0], lineOffset)
+
+	return documentNode.Content[0]
+}
+
+// mergeLocalIncludes merges the top-level keys of the files of the local includes of a file into
+// the top-level keys of the file. Locations containing CI/CD variables or input interpolations and
+// files that cannot be read are skipped with a warning.
+//
+// Parameters:
+//   - mappingNode: The top-level mapping of the including file.
+//   - includingFilePaths: The paths of the files that include the file, starting with the component file
+//     and ending with the file itself. They are used to detect recursive includes.
+//
+// Returns:
+//   - *yaml.Node: The merged top-level mapping.
+func (loader *componentLoader) mergeLocalIncludes(mappingNode *yaml.Node, includingFilePaths []string) *yaml.Node {
+	mergedNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	isComponentFile := len(includingFilePaths) == 1
+
+	var includes []Include
+	if includeNode := mappingValue(mappingNode, "include"); includeNode != nil {
+		includes = parseIncludes(*includeNode)
+	}
+
+	for _, include := range includes {
+		if include.Kind != IncludeKindLocal {
+			if !isComponentFile {
+				loader.includes = append(loader.includes, include)
+			}
+
+			continue
+		}
+
+		if loader.filesystem == nil {
+			continue
+		}
+
+		if strings.Contains(include.Location, "$") {
+			log.WithField("location", include.Location).
+				Warn("Ignoring local include whose location contains variables or inputs")
+
+			continue
+		}
+
+		for _, includedFilePath := range expandLocalInclude(loader.filesystem, include.Location) {
+			if slices.Contains(includingFilePaths, includedFilePath) {
+				log.WithField("filePath", includedFilePath).Warn("Ignoring recursive include")
+
+				continue
+			}
+
+			includedFileContent, err := afero.ReadFile(loader.filesystem, includedFilePath)
+			if err != nil {
+				log.WithField("filePath", includedFilePath).Warnf("Ignoring included file that cannot be read: %v", err)
+
+				continue
+			}
+
+			includedNode := loader.mergeLocalIncludes(
+				loader.readFile(includedFilePath, includedFileContent),
+				append(slices.Clone(includingFilePaths), includedFilePath),
+			)
+			// The specification and the includes only apply to the file that defines them.
+			includedNode = withoutMappingKey(withoutMappingKey(includedNode, "spec"), "include")
+			mergedNode = mergeTopLevelNodes(mergedNode, includedNode)
+		}
+	}
+
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		key := mappingNode.Content[i].Value
+		if isComponentFile {
+			delete(loader.sources, key)
+		} else {
+			loader.sources[key] = includingFilePaths[len(includingFilePaths)-1]
+		}
+	}
+
+	return mergeTopLevelNodes(mergedNode, mappingNode)
+}
+
+// setInterpolationSources translates the lines of interpolations into lines of the files
+// containing them and sets the paths of included files as their source.
+//
+// Parameters:
+//   - interpolations: The interpolations with lines of all files. They are modified in place.
+func (loader *componentLoader) setInterpolationSources(interpolations []InputInterpolation) {
+	for index := range interpolations {
+		interpolation := &interpolations[index]
+
+		for fileIndex, file := range loader.files {
+			if interpolation.Line <= file.lineOffset || interpolation.Line > file.lineOffset+file.lineCount {
+				continue
+			}
+
+			interpolation.Line -= file.lineOffset
+			if fileIndex > 0 {
+				interpolation.Source = file.path
+			}
+
+			break
+		}
+	}
+}
+
+// mergeTopLevelNodes deep-merges the top-level keys of two files. The comment of a key
+// is kept from the base if the override does not have one.
+//
+// Parameters:
+//   - base: The top-level mapping whose keys are overridden.
+//   - override: The top-level mapping whose keys take precedence.
+//
+// Returns:
+//   - *yaml.Node: A new node containing the merged keys.
+func mergeTopLevelNodes(base *yaml.Node, override *yaml.Node) *yaml.Node {
+	mergedNode := deepMergeNodes(base, override)
+
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		baseKeyNode := mappingKey(base, override.Content[i].Value)
+		if baseKeyNode == nil || override.Content[i].HeadComment != "" {
+			continue
+		}
+
+		mergedKeyNode := mappingKey(mergedNode, override.Content[i].Value)
+		mergedKeyNode.HeadComment = baseKeyNode.HeadComment
+	}
+
+	return mergedNode
+}
+
+// mappingKey returns the key node of a mapping with the given key.
+//
+// Parameters:
+//   - mappingNode: The mapping node.
+//   - key: The key to look up.
+//
+// Returns:
+//   - *yaml.Node: The key node, or nil if the mapping does not contain the key.
+func mappingKey(mappingNode *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		if mappingNode.Content[i].Value == key {
+			return mappingNode.Content[i]
+		}
+	}
+
+	return nil
+}
+
+// shiftNodeLines adds an offset to the line of a node and of all its children.
+//
+// Parameters:
+//   - node: The node to shift. It is modified in place.
+//   - lineOffset: The number of lines to add.
+func shiftNodeLines(node *yaml.Node, lineOffset int) {
+	node.Line += lineOffset
+
+	for _, child := range node.Content {
+		shiftNodeLines(child, lineOffset)
+	}
+}
+
+// expandLocalInclude returns the files of a local include. Locations with wildcards match all files
+// of the pattern, like `/templates/jobs/*.yml` all files of the directory and `/templates/jobs/**.yml`
+// all files of the directory and its subdirectories.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - location: The location of the local include, e.g. "/templates/jobs.yml".
+//
+// Returns:
+//   - []string: The paths of the files relative to the repository root, in lexical order.
+func expandLocalInclude(filesystem afero.Fs, location string) []string {
+	filePath := strings.TrimPrefix(filepath.Clean("/"+location), "/")
+	if !strings.Contains(filePath, "*") {
+		return []string{filePath}
+	}
+
+	pattern := localIncludePattern(filePath)
+	// Only the directory before the first wildcard can contain matching files.
+	rootDirectory := filepath.Dir(filePath[:strings.Index(filePath, "*")])
+
+	var filePaths []string
+
+	err := afero.Walk(filesystem, rootDirectory, func(walkedPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if !info.IsDir() && pattern.MatchString(filepath.ToSlash(walkedPath)) {
+			filePaths = append(filePaths, walkedPath)
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.WithField("location", location).Fatalf("failed to expand local include: %v", err)
+	}
+
+	slices.Sort(filePaths)
+
+	return filePaths
+}
+
+// localIncludePattern converts the location of a local include with wildcards into a regex the way
+// GitLab matches it. `**` matches any characters, including slashes, and `*` matches any characters
+// except slashes. All other characters match themselves.
+//
+// Parameters:
+//   - filePath: The location relative to the repository root, e.g. "templates/**/*.yml".
+//
+// Returns:
+//   - *regexp.Regexp: The regex matching the paths of the files relative to the repository root.
+func localIncludePattern(filePath string) *regexp.Regexp {
+	parts := strings.Split(filePath, "**")
+	for index, part := range parts {
+		parts[index] = strings.ReplaceAll(regexp.QuoteMeta(part), `\*`, "[^/]*")
+	}
+
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+// includedComponents returns the locations of the component includes.
+//
+// Parameters:
+//   - includes: The includes of a configuration.
+//
+// Returns:
+//   - []string: The component references, e.g. "$CI_SERVER_FQDN/group/project/build@1.0.0".
+func includedComponents(includes []Include) []string {
+	var components []string
+
+	for _, include := range includes {
+		if include.Kind == IncludeKindComponent {
+			components = append(components, include.Location)
+		}
+	}
+
+	return components
+}
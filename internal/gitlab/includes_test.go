@@ -0,0 +1,240 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalYAMLParsesIncludes(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `include:
+  - "/templates/jobs.yml"
+  - "https://example.com/ci.yml"
+  - local: "templates/other.yml"
+  - component: "$CI_SERVER_FQDN/group/project/build@1.0.0"
+  - project: "group/project"
+    file: "ci.yml"
+  - template: "Jobs/SAST.gitlab-ci.yml"
+job:
+  script: "echo job"
+`
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Include{
+		{Kind: IncludeKindLocal, Location: "/templates/jobs.yml"},
+		{Kind: IncludeKindRemote, Location: "https://example.com/ci.yml"},
+		{Kind: IncludeKindLocal, Location: "templates/other.yml"},
+		{Kind: IncludeKindComponent, Location: "$CI_SERVER_FQDN/group/project/build@1.0.0"},
+		{Kind: IncludeKindProject, Location: "group/project"},
+		{Kind: IncludeKindTemplate, Location: "Jobs/SAST.gitlab-ci.yml"},
+	}, gitlabCiConfig.Includes)
+}
+
+func TestUnmarshalYAMLParsesSingleInclude(t *testing.T) {
+	t.Parallel()
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte("include: \"/templates/jobs.yml\"\njob:\n  script: \"echo job\"\n"), &gitlabCiConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Include{{Kind: IncludeKindLocal, Location: "/templates/jobs.yml"}}, gitlabCiConfig.Includes)
+}
+
+func TestParseComponentFileMergesLocalIncludes(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/jobs/build.yml", []byte(`include:
+  - local: "/templates/jobs/base.yml"
+  - component: "$CI_SERVER_FQDN/group/project/lint@1.0.0"
+build:
+  extends: ".base"
+  script: "make build"
+# Included job
+job:
+  stage: "test"
+  script: "echo included"
+`), 0o644)
+	require.NoError(t, err)
+	err = afero.WriteFile(filesystem, "templates/jobs/base.yml", []byte(`include:
+  - local: "/templates/component.yml"
+.base:
+  image: "alpine"
+`), 0o644)
+	require.NoError(t, err)
+
+	gitlabCiConfig := parseComponentFile(filesystem, "templates/component.yml", []byte(`include:
+  - local: "/templates/jobs/*.yml"
+.setup:
+  before_script: "setup"
+job:
+  script:
+    - !reference [.base, image]
+`))
+
+	require.Len(t, gitlabCiConfig.Jobs, 2)
+	assert.Equal(t, "build", gitlabCiConfig.Jobs[0].Name)
+	assert.Equal(t, "templates/jobs/build.yml", gitlabCiConfig.Jobs[0].Source)
+	assert.Equal(t, []string{".base"}, gitlabCiConfig.Jobs[0].ExtendsChain)
+	assert.Equal(t, "alpine", gitlabCiConfig.Jobs[0].Images[0].Reference)
+
+	assert.Equal(t, "job", gitlabCiConfig.Jobs[1].Name)
+	assert.Empty(t, gitlabCiConfig.Jobs[1].Source)
+	assert.Equal(t, "Included job", gitlabCiConfig.Jobs[1].Comment)
+	assert.Equal(t, "test", gitlabCiConfig.Jobs[1].Stage)
+	assert.Equal(
+		t,
+		[]JobScript{{Keyword: "script", Lines: []string{"alpine"}}},
+		gitlabCiConfig.Jobs[1].Scripts,
+	)
+
+	require.Len(t, gitlabCiConfig.HiddenJobs, 2)
+	assert.Equal(t, ".base", gitlabCiConfig.HiddenJobs[0].Name)
+	assert.Equal(t, "templates/jobs/base.yml", gitlabCiConfig.HiddenJobs[0].Source)
+	assert.Equal(t, []string{"build"}, gitlabCiConfig.HiddenJobs[0].ExtendedBy)
+	assert.Equal(t, ".setup", gitlabCiConfig.HiddenJobs[1].Name)
+	assert.Empty(t, gitlabCiConfig.HiddenJobs[1].Source)
+
+	assert.Equal(
+		t,
+		[]string{"$CI_SERVER_FQDN/group/project/lint@1.0.0"},
+		includedComponents(gitlabCiConfig.Includes),
+	)
+}
+
+func TestExpandLocalIncludeMatchesWildcardsLikeGitLab(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	for _, filePath := range []string{
+		"templates/jobs/build.yml",
+		"templates/jobs/README.md",
+		"templates/jobs/deploy/production/deploy.yml",
+		"templates/component.yml",
+	} {
+		require.NoError(t, afero.WriteFile(filesystem, filePath, []byte("job: {}\n"), 0o644))
+	}
+
+	assert.Equal(t, []string{"templates/jobs/build.yml"}, expandLocalInclude(filesystem, "/templates/jobs/*.yml"))
+	assert.Equal(
+		t,
+		[]string{"templates/jobs/build.yml", "templates/jobs/deploy/production/deploy.yml"},
+		expandLocalInclude(filesystem, "/templates/jobs/**.yml"),
+	)
+	assert.Equal(
+		t,
+		[]string{"templates/jobs/deploy/production/deploy.yml"},
+		expandLocalInclude(filesystem, "/templates/jobs/**/*.yml"),
+	)
+	assert.Equal(t, []string{"templates/component.yml"}, expandLocalInclude(filesystem, "templates/*.yml"))
+	assert.Len(t, expandLocalInclude(filesystem, "**.yml"), 3)
+	assert.Empty(t, expandLocalInclude(filesystem, "/missing/**.yml"))
+}
+
+func TestParseComponentFileSkipsDynamicAndMissingLocalIncludes(t *testing.T) {
+	t.Parallel()
+
+	gitlabCiConfig := parseComponentFile(afero.NewMemMapFs(), "templates/component.yml", []byte(`include:
+  - local: "/ci/$[[ inputs.variant ]].yml"
+  - local: "/ci/$CI_JOB_NAME.yml"
+  - local: "/ci/missing.yml"
+job:
+  script: "echo job"
+`))
+
+	require.Len(t, gitlabCiConfig.Jobs, 1)
+	assert.Equal(t, "job", gitlabCiConfig.Jobs[0].Name)
+}
+
+func TestParseComponentFileLocatesInterpolationsOfIncludedFiles(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/jobs.yml", []byte(`.template:
+  script:
+    - echo $[[ inputs.message ]]
+`), 0o644)
+	require.NoError(t, err)
+
+	gitlabCiConfig := parseComponentFile(filesystem, "templates/component.yml", []byte(`---
+spec:
+  inputs:
+    message: {}
+---
+include:
+  - local: "/templates/jobs.yml"
+job:
+  extends: ".template"
+  after_script:
+    - echo $[[ inputs.message ]]
+`))
+
+	require.Len(t, gitlabCiConfig.Jobs, 1)
+	assert.Equal(t, []InputInterpolation{
+		{
+			Input:   "message",
+			Text:    "$[[ inputs.message ]]",
+			Keyword: "script",
+			Line:    3,
+			Column:  12,
+			Source:  "templates/jobs.yml",
+		},
+		{Input: "message", Text: "$[[ inputs.message ]]", Keyword: "after_script", Line: 11, Column: 12},
+	}, gitlabCiConfig.Jobs[0].InputInterpolations)
+}
+
+func TestGenerateDocumentationRendersIncludes(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+spec:
+  inputs: {}
+...
+---
+include:
+  - local: "/templates/jobs/build.yml"
+  - component: "$CI_SERVER_FQDN/group/project/lint@1.0.0"
+test:
+  script: "make test"
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+	err = afero.WriteFile(
+		filesystem, "templates/jobs/build.yml", []byte("# Builds\nbuild:\n  script: \"make\"\n"), 0o644,
+	)
+	require.NoError(t, err)
+
+	documentationGenerator := &RealDocumentationGenerator{}
+	documentationGenerator.GenerateDocumentation(
+		filesystem,
+		"templates",
+		"resources/default-template.md.gotmpl",
+		"gitlab.com/group/project",
+		"1.0.0",
+		"README.md",
+		false,
+		true,
+		".labdoc.yml",
+		nil,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(outputContent),
+		"##### `build`\n\nBuilds\n\nDefined in the included file `templates/jobs/build.yml`.\n")
+	assert.Contains(t, string(outputContent), "#### Included components of component `component`\n\n"+
+		"The component includes the following components, which also add their jobs to your CI/CD Pipeline.\n\n"+
+		"- `$CI_SERVER_FQDN/group/project/lint@1.0.0`\n")
+}
@@ -0,0 +1,234 @@
+package gitlab
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// InputTypeString is the type of inputs without a `type`.
+	InputTypeString = "string"
+	// InputTypeNumber is the type of inputs with integer or floating point values.
+	InputTypeNumber = "number"
+	// InputTypeBoolean is the type of inputs with the values `true` and `false`.
+	InputTypeBoolean = "boolean"
+	// InputTypeArray is the type of inputs with a list of values.
+	InputTypeArray = "array"
+)
+
+// validateInput checks an input the way GitLab does when the component is included:
+// The type must be known, `options` are only allowed for strings and numbers, `regex` only
+// for strings, and the `default` must have the type, be one of the `options` and match the `regex`.
+// The `regex` must also be supported by RE2 and match the values of the `@valid` annotations.
+//
+// Parameters:
+//   - input: The input to check.
+//
+// Returns:
+//   - []error: An error for every violation. Empty if the input is valid.
+func validateInput(input Input) []error {
+	errs := []error{}
+
+	inputType := input.Type
+	if inputType == "" {
+		inputType = InputTypeString
+	}
+
+	if !slices.Contains([]string{InputTypeString, InputTypeNumber, InputTypeBoolean, InputTypeArray}, inputType) {
+		return append(errs, fmt.Errorf("input %q has the unknown type %q", input.Name, inputType))
+	}
+
+	if len(input.Options) > 0 && inputType != InputTypeString && inputType != InputTypeNumber {
+		errs = append(errs, fmt.Errorf("input %q of type %q must not have options", input.Name, inputType))
+	}
+
+	if input.Regex != "" && inputType != InputTypeString {
+		errs = append(errs, fmt.Errorf("input %q of type %q must not have a regex", input.Name, inputType))
+	} else if input.Regex != "" {
+		errs = append(errs, validateInputRegex(input)...)
+	}
+
+	if input.Default == nil {
+		return errs
+	}
+
+	if !hasInputType(input.Default, inputType) {
+		return append(errs, fmt.Errorf(
+			"default %v of input %q is not of type %q",
+			formatInputValue(input.Default), input.Name, inputType,
+		))
+	}
+
+	if len(input.Options) > 0 && !slices.ContainsFunc(input.Options, func(option interface{}) bool {
+		return formatInputValue(option) == formatInputValue(input.Default)
+	}) {
+		errs = append(errs, fmt.Errorf(
+			"default %v of input %q is not one of the options", formatInputValue(input.Default), input.Name,
+		))
+	}
+
+	return errs
+}
+
+// validateInputRegex checks that the regex of an input can be compiled by RE2, which GitLab uses
+// to evaluate it, and tests it against the default and the values of the `@valid` and `@invalid`
+// annotations.
+//
+// Parameters:
+//   - input: The input to check. Its regex must not be empty.
+//
+// Returns:
+//   - []error: An error for every violation. Empty if the regex is valid.
+func validateInputRegex(input Input) []error {
+	construct := unsupportedRegexConstruct(input.Regex)
+	if construct != "" {
+		return []error{fmt.Errorf("regex of input %q uses %s, which RE2 does not support", input.Name, construct)}
+	}
+
+	regex, err := regexp.Compile(input.Regex)
+	if err != nil {
+		return []error{fmt.Errorf("regex of input %q is invalid: %w", input.Name, err)}
+	}
+
+	errs := []error{}
+
+	if defaultValue, isString := input.Default.(string); isString && !regex.MatchString(defaultValue) {
+		errs = append(errs, fmt.Errorf(
+			"default %q of input %q does not match the regex %q", defaultValue, input.Name, input.Regex,
+		))
+	}
+
+	for _, validValue := range input.Annotations.ValidValues {
+		if !regex.MatchString(validValue) {
+			errs = append(errs, fmt.Errorf(
+				"valid value %q of input %q does not match the regex %q", validValue, input.Name, input.Regex,
+			))
+		}
+	}
+
+	for _, invalidValue := range input.Annotations.InvalidValues {
+		if regex.MatchString(invalidValue) {
+			errs = append(errs, fmt.Errorf(
+				"invalid value %q of input %q matches the regex %q", invalidValue, input.Name, input.Regex,
+			))
+		}
+	}
+
+	return errs
+}
+
+// unsupportedRegexConstruct finds constructs of other regex flavors that RE2 does not support,
+// like lookarounds and backreferences. Escaped characters and character classes, including POSIX classes
+// like `[[:alpha:]]`, are skipped.
+//
+// Parameters:
+//   - regex: The regex to check.
+//
+// Returns:
+//   - string: A description of the first unsupported construct. Empty if there is none.
+func unsupportedRegexConstruct(regex string) string {
+	inCharacterClass := false
+
+	for i := 0; i < len(regex); i++ {
+		switch {
+		case regex[i] == '\\' && i+1 < len(regex):
+			if !inCharacterClass && (regex[i+1] >= '1' && regex[i+1] <= '9' || regex[i+1] == 'k') {
+				return fmt.Sprintf("the backreference `%s`", regex[i:i+2])
+			}
+
+			i++
+		case inCharacterClass && strings.HasPrefix(regex[i:], "[:"):
+			// POSIX classes like `[:alpha:]` end with `:]`, not with the `]` of the character class.
+			if end := strings.Index(regex[i+2:], ":]"); end != -1 {
+				i += end + 3
+			}
+		case regex[i] == '[' && !inCharacterClass:
+			inCharacterClass = true
+
+			// A `]` right after the opening bracket or its negation is a literal.
+			if strings.HasPrefix(regex[i+1:], "^") {
+				i++
+			}
+
+			if strings.HasPrefix(regex[i+1:], "]") {
+				i++
+			}
+		case regex[i] == ']':
+			inCharacterClass = false
+		case inCharacterClass:
+			continue
+		case strings.HasPrefix(regex[i:], "(?=") || strings.HasPrefix(regex[i:], "(?!"):
+			return fmt.Sprintf("the lookahead `%s`", regex[i:i+3])
+		case strings.HasPrefix(regex[i:], "(?<=") || strings.HasPrefix(regex[i:], "(?<!"):
+			return fmt.Sprintf("the lookbehind `%s`", regex[i:i+4])
+		case strings.HasPrefix(regex[i:], "(?>"):
+			return "the atomic group `(?>`"
+		}
+	}
+
+	return ""
+}
+
+// reportInvalidInputs logs every violation of the inputs and exits if there is at least one.
+//
+// Parameters:
+//   - components: The components whose inputs are checked.
+func reportInvalidInputs(components []Component) {
+	errorCount := 0
+
+	for _, component := range components {
+		for _, input := range component.Inputs {
+			for _, err := range validateInput(input) {
+				log.WithField("component", component.Name).Error(err)
+
+				errorCount++
+			}
+		}
+	}
+
+	if errorCount > 0 {
+		log.WithField("errorCount", errorCount).Fatal("Found invalid inputs")
+	}
+}
+
+// hasInputType checks if a value parsed from YAML has the given input type.
+//
+// Parameters:
+//   - value: The value to check.
+//   - inputType: The input type, e.g. "number".
+//
+// Returns:
+//   - bool: True if the value has the input type.
+func hasInputType(value interface{}, inputType string) bool {
+	switch value.(type) {
+	case string:
+		return inputType == InputTypeString
+	case int, int64, uint64, float64:
+		return inputType == InputTypeNumber
+	case bool:
+		return inputType == InputTypeBoolean
+	case []interface{}:
+		return inputType == InputTypeArray
+	default:
+		return false
+	}
+}
+
+// formatInputValue formats a value of an input for messages, quoting strings.
+//
+// Parameters:
+//   - value: The value to format.
+//
+// Returns:
+//   - string: The formatted value.
+func formatInputValue(value interface{}) string {
+	if stringValue, isString := value.(string); isString {
+		return fmt.Sprintf("%q", stringValue)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,130 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateInputAcceptsValidDefaults(t *testing.T) {
+	t.Parallel()
+
+	inputs := []Input{
+		{Name: "mandatory"},
+		{Name: "string", Default: "value"},
+		{Name: "number", Type: InputTypeNumber, Default: 1.5},
+		{Name: "integer", Type: InputTypeNumber, Default: 3, Options: []interface{}{1, 3}},
+		{Name: "boolean", Type: InputTypeBoolean, Default: false},
+		{Name: "array", Type: InputTypeArray, Default: []interface{}{"a", "b"}},
+		{Name: "options", Default: "b", Options: []interface{}{"a", "b"}},
+		{Name: "regex", Type: InputTypeString, Default: "v1.2", Regex: `^v\d+\.\d+$`},
+	}
+
+	for _, input := range inputs {
+		assert.Empty(t, validateInput(input), input.Name)
+	}
+}
+
+func TestValidateInputReportsInvalidInputs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		input         Input
+		expectedError string
+	}{
+		{
+			Input{Name: "unknown", Type: "list", Default: "value"},
+			`input "unknown" has the unknown type "list"`,
+		},
+		{
+			Input{Name: "string", Default: 1},
+			`default 1 of input "string" is not of type "string"`,
+		},
+		{
+			Input{Name: "number", Type: InputTypeNumber, Default: "1"},
+			`default "1" of input "number" is not of type "number"`,
+		},
+		{
+			Input{Name: "boolean", Type: InputTypeBoolean, Default: "true"},
+			`default "true" of input "boolean" is not of type "boolean"`,
+		},
+		{
+			Input{Name: "array", Type: InputTypeArray, Default: "a"},
+			`default "a" of input "array" is not of type "array"`,
+		},
+		{
+			Input{Name: "options", Default: "c", Options: []interface{}{"a", "b"}},
+			`default "c" of input "options" is not one of the options`,
+		},
+		{
+			Input{Name: "regex", Default: "1.2", Regex: `^v\d+\.\d+$`},
+			`default "1.2" of input "regex" does not match the regex "^v\\d+\\.\\d+$"`,
+		},
+		{
+			Input{Name: "lookahead", Regex: `^(?!main$).+`},
+			"regex of input \"lookahead\" uses the lookahead `(?!`, which RE2 does not support",
+		},
+		{
+			Input{Name: "lookbehind", Regex: `(?<=v)\d+`},
+			"regex of input \"lookbehind\" uses the lookbehind `(?<=`, which RE2 does not support",
+		},
+		{
+			Input{Name: "backreference", Regex: `^(a)\1$`},
+			"regex of input \"backreference\" uses the backreference `\\1`, which RE2 does not support",
+		},
+		{
+			Input{Name: "valid-value", Regex: `^v\d+$`, Annotations: Annotations{ValidValues: []string{"1"}}},
+			`valid value "1" of input "valid-value" does not match the regex "^v\\d+$"`,
+		},
+		{
+			Input{Name: "invalid-value", Regex: `^v\d+$`, Annotations: Annotations{InvalidValues: []string{"v1"}}},
+			`invalid value "v1" of input "invalid-value" matches the regex "^v\\d+$"`,
+		},
+		{
+			Input{Name: "invalid-regex", Default: "a", Regex: "(a"},
+			"regex of input \"invalid-regex\" is invalid: error parsing regexp: missing closing ): `(a`",
+		},
+		{
+			Input{Name: "boolean-options", Type: InputTypeBoolean, Options: []interface{}{true}},
+			`input "boolean-options" of type "boolean" must not have options`,
+		},
+		{
+			Input{Name: "number-regex", Type: InputTypeNumber, Regex: `^\d$`},
+			`input "number-regex" of type "number" must not have a regex`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		errs := validateInput(testCase.input)
+		if assert.Len(t, errs, 1, testCase.input.Name) {
+			assert.EqualError(t, errs[0], testCase.expectedError)
+		}
+	}
+}
+
+func TestValidateInputAcceptsMatchingExampleValues(t *testing.T) {
+	t.Parallel()
+
+	input := Input{
+		Name:  "version",
+		Regex: `^v\d+$`,
+		Annotations: Annotations{
+			ValidValues:   []string{"v1", "v10"},
+			InvalidValues: []string{"1", ""},
+		},
+	}
+
+	assert.Empty(t, validateInput(input))
+}
+
+func TestUnsupportedRegexConstructSkipsEscapesAndCharacterClasses(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, unsupportedRegexConstruct(`^\(?=[(?=\1]\\$`))
+	assert.Equal(t, "the atomic group `(?>`", unsupportedRegexConstruct(`(?>a+)b`))
+	assert.Equal(t, "the backreference `\\k`", unsupportedRegexConstruct(`(?<a>x)\k<a>`))
+	assert.Empty(t, unsupportedRegexConstruct(`^[[:alpha:]_]+$`))
+	assert.Empty(t, unsupportedRegexConstruct(`^[[:alpha:](?=_]+$`))
+	assert.Empty(t, unsupportedRegexConstruct(`^[]\1]+$`))
+	assert.Equal(t, "the lookahead `(?=`", unsupportedRegexConstruct(`^[[:alpha:]_]+(?=x)`))
+}
@@ -0,0 +1,228 @@
+package gitlab
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// inputInterpolationPattern matches interpolations of inputs, like `$[[ inputs.stage ]]`
+// or `$[[ inputs.stage | truncate(0,5) ]]`. The first group is the name of the input.
+var inputInterpolationPattern = regexp.MustCompile(`\$\[\[\s*inputs\.([A-Za-z0-9_-]+)[^\]]*\]\]`)
+
+// InputInterpolation is an interpolation of an input, like `$[[ inputs.stage ]]`, in a job keyword.
+type InputInterpolation struct {
+	// Input is the name of the interpolated input.
+	Input string
+	// Text is the interpolation as written in the file, e.g. "$[[ inputs.stage ]]".
+	Text string
+	// Keyword is the job keyword containing the interpolation, e.g. "script" or "rules:if".
+	Keyword string
+	// Line is the line of the interpolation in the file containing it.
+	Line int
+	// Column is the column of the interpolation in the file containing it.
+	Column int
+	// Source is the path of the locally included file containing the interpolation.
+	// Empty if the interpolation is in the component file.
+	Source string
+}
+
+// findInputInterpolations finds the input interpolations in the `script`, `before_script`,
+// `after_script`, `rules:if` and `image` keywords of a job.
+//
+// Parameters:
+//   - jobNode: The mapping node of the effective job configuration.
+//
+// Returns:
+//   - []InputInterpolation: The interpolations, in order of their keywords in the job.
+func findInputInterpolations(jobNode *yaml.Node) []InputInterpolation {
+	var interpolations []InputInterpolation
+
+	for i := 0; i+1 < len(jobNode.Content); i += 2 {
+		keyword := jobNode.Content[i].Value
+		valueNode := jobNode.Content[i+1]
+
+		switch keyword {
+		case "script", "before_script", "after_script":
+			interpolations = append(interpolations, findInterpolationsInScalars(valueNode, keyword)...)
+		case "image":
+			if valueNode.Kind == yaml.MappingNode {
+				valueNode = mappingValue(valueNode, "name")
+			}
+
+			interpolations = append(interpolations, findInterpolationsInScalars(valueNode, keyword)...)
+		case "rules":
+			if valueNode.Kind != yaml.SequenceNode {
+				continue
+			}
+
+			for _, ruleNode := range valueNode.Content {
+				if ruleNode.Kind == yaml.MappingNode {
+					interpolations = append(
+						interpolations,
+						findInterpolationsInScalars(mappingValue(ruleNode, "if"), "rules:if")...,
+					)
+				}
+			}
+		}
+	}
+
+	return interpolations
+}
+
+// findGlobalInputInterpolations finds the input interpolations in the `image`, `before_script` and
+// `after_script` keywords of `default` and of the top level, which apply to all jobs.
+//
+// Parameters:
+//   - node: The top-level mapping node of the configuration file.
+//
+// Returns:
+//   - []InputInterpolation: The interpolations of `default`, with keywords prefixed with "default:",
+//     followed by the interpolations of the top level.
+func findGlobalInputInterpolations(node *yaml.Node) []InputInterpolation {
+	var interpolations []InputInterpolation
+
+	globalKeywords := []string{"image", "before_script", "after_script"}
+
+	for _, prefix := range []string{"default:", ""} {
+		keywordsNode := node
+		if prefix != "" {
+			keywordsNode = mappingValue(node, "default")
+		}
+
+		if keywordsNode == nil || keywordsNode.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for _, interpolation := range findInputInterpolations(keywordsNode) {
+			if !slices.Contains(globalKeywords, interpolation.Keyword) {
+				continue
+			}
+
+			interpolation.Keyword = prefix + interpolation.Keyword
+			interpolations = append(interpolations, interpolation)
+		}
+	}
+
+	return interpolations
+}
+
+// findInterpolationsInScalars finds the input interpolations in a scalar or in all scalars of
+// a (nested) sequence. The line breaks of folded and quoted scalars are lost in their values,
+// so the position of each interpolation is the start of its scalar. locateInputInterpolations
+// refines the positions.
+//
+// Parameters:
+//   - node: The node to search. Nil nodes have no interpolations.
+//   - keyword: The job keyword containing the node.
+//
+// Returns:
+//   - []InputInterpolation: The interpolations of the scalars.
+func findInterpolationsInScalars(node *yaml.Node, keyword string) []InputInterpolation {
+	var interpolations []InputInterpolation
+
+	if node == nil {
+		return interpolations
+	}
+
+	if node.Kind == yaml.SequenceNode {
+		for _, item := range node.Content {
+			interpolations = append(interpolations, findInterpolationsInScalars(item, keyword)...)
+		}
+
+		return interpolations
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return interpolations
+	}
+
+	for _, match := range inputInterpolationPattern.FindAllStringSubmatch(node.Value, -1) {
+		interpolations = append(interpolations, InputInterpolation{
+			Input:   match[1],
+			Text:    match[0],
+			Keyword: keyword,
+			Line:    node.Line,
+			Column:  node.Column,
+		})
+	}
+
+	return interpolations
+}
+
+// resolveInputDefaults replaces the input interpolations of a text with the defaults of the inputs.
+// Interpolations of inputs without a default and interpolations that use functions, like
+// `$[[ inputs.name | truncate(0,5) ]]`, are kept.
+//
+// Parameters:
+//   - text: The text containing interpolations, e.g. "$[[ inputs.prefix ]]-build".
+//   - inputs: The inputs of the component.
+//
+// Returns:
+//   - string: The text with the resolved interpolations, e.g. "docs-build".
+//   - []string: The names of all interpolated inputs, in order of their interpolations.
+func resolveInputDefaults(text string, inputs []Input) (string, []string) {
+	var inputNames []string
+
+	resolvedText := text
+
+	for _, match := range inputInterpolationPattern.FindAllStringSubmatch(text, -1) {
+		inputNames = append(inputNames, match[1])
+
+		inputIndex := slices.IndexFunc(inputs, func(input Input) bool { return input.Name == match[1] })
+		if inputIndex == -1 || inputs[inputIndex].Default == nil || strings.Contains(match[0], "|") {
+			continue
+		}
+
+		resolvedText = strings.Replace(resolvedText, match[0], fmt.Sprint(inputs[inputIndex].Default), 1)
+	}
+
+	return resolvedText, inputNames
+}
+
+// locateInputInterpolations sets the line and column of each interpolation to the position at which
+// its text appears in the file. The text is searched in the raw lines from the start of its scalar on,
+// and after the previous interpolation of the same scalar. Interpolations that are not found keep
+// the position of their scalar.
+//
+// Parameters:
+//   - interpolations: The interpolations to locate. They are modified in place.
+//   - yamlContent: The content of the files containing the interpolations.
+func locateInputInterpolations(interpolations []InputInterpolation, yamlContent []byte) {
+	lines := strings.Split(string(yamlContent), "\n")
+
+	var scalarLine, scalarColumn, searchLine, searchOffset int
+
+	for index := range interpolations {
+		interpolation := &interpolations[index]
+
+		// Interpolations of the same scalar are consecutive and have the position of the scalar.
+		if interpolation.Line != scalarLine || interpolation.Column != scalarColumn {
+			scalarLine, scalarColumn = interpolation.Line, interpolation.Column
+			searchLine, searchOffset = interpolation.Line, interpolation.Column-1
+		}
+
+		for lineNumber := max(searchLine, 1); lineNumber <= len(lines); lineNumber++ {
+			line := lines[lineNumber-1]
+
+			offset := 0
+			if lineNumber == searchLine {
+				offset = min(max(searchOffset, 0), len(line))
+			}
+
+			textIndex := strings.Index(line[offset:], interpolation.Text)
+			if textIndex == -1 {
+				continue
+			}
+
+			interpolation.Line = lineNumber
+			interpolation.Column = offset + textIndex + 1
+			searchLine, searchOffset = lineNumber, offset+textIndex+len(interpolation.Text)
+
+			break
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseYamlFileFindsInputInterpolationsWithPositions(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := []byte(`---
+spec:
+  inputs:
+    image: {}
+...
+---
+job:
+  image:
+    name: "$[[ inputs.image ]]"
+  script:
+    - echo "$[[ inputs.message ]]" $[[ inputs.message ]]
+    - |
+      echo start
+      run $[[inputs.command|truncate(0,3)]]
+  rules:
+    - if: $CI_COMMIT_BRANCH == "$[[ inputs.branch ]]"
+  variables:
+    IGNORED: $[[ inputs.variable ]]
+`)
+
+	gitlabCiConfig := parseYamlFileWithoutSeparatorsToGitLabCiConfig(yamlContent)
+
+	expectedInterpolations := []InputInterpolation{
+		{Input: "image", Text: "$[[ inputs.image ]]", Keyword: "image", Line: 9, Column: 12},
+		{Input: "message", Text: "$[[ inputs.message ]]", Keyword: "script", Line: 11, Column: 13},
+		{Input: "message", Text: "$[[ inputs.message ]]", Keyword: "script", Line: 11, Column: 36},
+		{Input: "command", Text: "$[[inputs.command|truncate(0,3)]]", Keyword: "script", Line: 14, Column: 11},
+		{Input: "branch", Text: "$[[ inputs.branch ]]", Keyword: "rules:if", Line: 16, Column: 33},
+	}
+	assert.Equal(t, expectedInterpolations, gitlabCiConfig.Jobs[0].InputInterpolations)
+}
+
+func TestParseYamlFileLocatesInterpolationsInFoldedAndQuotedScalars(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := []byte(`job:
+  script:
+    - >
+      eval "labdoc generate
+      --repoUrl $[[ inputs.repo-url ]]
+      --outputFile $[[ inputs.output-file-path ]]"
+    - "echo $[[ inputs.first ]]
+      $[[ inputs.second ]] $[[ inputs.first ]]"
+`)
+
+	gitlabCiConfig := parseYamlFileWithoutSeparatorsToGitLabCiConfig(yamlContent)
+
+	expectedInterpolations := []InputInterpolation{
+		{Input: "repo-url", Text: "$[[ inputs.repo-url ]]", Keyword: "script", Line: 5, Column: 17},
+		{Input: "output-file-path", Text: "$[[ inputs.output-file-path ]]", Keyword: "script", Line: 6, Column: 20},
+		{Input: "first", Text: "$[[ inputs.first ]]", Keyword: "script", Line: 7, Column: 13},
+		{Input: "second", Text: "$[[ inputs.second ]]", Keyword: "script", Line: 8, Column: 7},
+		{Input: "first", Text: "$[[ inputs.first ]]", Keyword: "script", Line: 8, Column: 28},
+	}
+	assert.Equal(t, expectedInterpolations, gitlabCiConfig.Jobs[0].InputInterpolations)
+}
+
+func TestFindInputInterpolationsIncludesExtendedKeywords(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := []byte(`.template:
+  after_script:
+    - cleanup $[[ inputs.target ]]
+job:
+  extends: .template
+`)
+
+	gitlabCiConfig := parseYamlFileWithoutSeparatorsToGitLabCiConfig(yamlContent)
+
+	expectedInterpolations := []InputInterpolation{
+		{Input: "target", Text: "$[[ inputs.target ]]", Keyword: "after_script", Line: 3, Column: 15},
+	}
+	assert.Equal(t, expectedInterpolations, gitlabCiConfig.Jobs[0].InputInterpolations)
+	assert.Equal(t, expectedInterpolations, gitlabCiConfig.HiddenJobs[0].InputInterpolations)
+}
+
+func TestParseYamlFileFindsInputInterpolationsOfDefaultAndTopLevel(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := []byte(`default:
+  image: "$[[ inputs.image ]]"
+  before_script:
+    - setup $[[ inputs.target ]]
+  tags:
+    - $[[ inputs.tag ]]
+after_script:
+  - cleanup $[[ inputs.target ]]
+job:
+  script: echo
+`)
+
+	gitlabCiConfig := parseYamlFileWithoutSeparatorsToGitLabCiConfig(yamlContent)
+
+	expectedInterpolations := []InputInterpolation{
+		{Input: "image", Text: "$[[ inputs.image ]]", Keyword: "default:image", Line: 2, Column: 11},
+		{Input: "target", Text: "$[[ inputs.target ]]", Keyword: "default:before_script", Line: 4, Column: 13},
+		{Input: "target", Text: "$[[ inputs.target ]]", Keyword: "after_script", Line: 8, Column: 13},
+	}
+	assert.Equal(t, expectedInterpolations, gitlabCiConfig.InputInterpolations)
+	assert.Empty(t, gitlabCiConfig.Jobs[0].InputInterpolations)
+}
+
+func TestResolveInputDefaultsReplacesInterpolationsWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	inputs := []Input{
+		{Name: "prefix", Default: "docs"},
+		{Name: "suffix"},
+	}
+
+	resolvedText, inputNames := resolveInputDefaults(
+		"$[[ inputs.prefix ]]-build-$[[ inputs.suffix ]]-$[[ inputs.prefix | truncate(0,1) ]]", inputs,
+	)
+
+	assert.Equal(t, "docs-build-$[[ inputs.suffix ]]-$[[ inputs.prefix | truncate(0,1) ]]", resolvedText)
+	assert.Equal(t, []string{"prefix", "suffix", "prefix"}, inputNames)
+
+	resolvedText, inputNames = resolveInputDefaults("build", inputs)
+
+	assert.Equal(t, "build", resolvedText)
+	assert.Nil(t, inputNames)
+}
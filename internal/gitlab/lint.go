@@ -0,0 +1,242 @@
+package gitlab
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// LintIssue represents a problem found by a lint rule.
+type LintIssue struct {
+	Rule      string
+	Component string
+	Message   string
+}
+
+// String returns a human-readable description of the LintIssue.
+func (issue LintIssue) String() string {
+	return fmt.Sprintf("%s: %s (%s)", issue.Component, issue.Message, issue.Rule)
+}
+
+// lintContext contains the data that lint rules check.
+type lintContext struct {
+	// Components are all components, including the ones hidden from the documentation.
+	Components []Component
+	// Baseline is the documentation data of a previous version. Nil if no baseline is given.
+	Baseline *ComponentsDocumentation
+}
+
+// lintRule is a named check that reports issues of the components.
+type lintRule struct {
+	Name  string
+	Check func(context lintContext) []LintIssue
+}
+
+// lintRules are all rules run by the Linter.
+var lintRules = []lintRule{
+	{Name: "stable-component-removed", Check: checkStableComponentRemovals},
+	{Name: "stable-input-removed", Check: checkStableInputRemovals},
+	{Name: "invalid-input", Check: checkInvalidInputs},
+	{Name: "script-injection", Check: checkUnsafeInterpolations("script", "before_script", "after_script")},
+	{Name: "rules-injection", Check: checkUnsafeInterpolations("rules:if")},
+	{Name: "image-injection", Check: checkUnsafeInterpolations("image")},
+	{Name: "image-not-pinned", Check: checkUnpinnedImages},
+}
+
+// Linter defines the interface for linting GitLab CI/CD components.
+type Linter interface {
+	Lint(filesystem afero.Fs, componentDirectory string, baselineFilePath string)
+}
+
+// RealLinter implements the Linter interface.
+type RealLinter struct{}
+
+// Lint checks all components in the directory with all lint rules. Each issue is logged
+// and the application exits if there is at least one issue.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - componentDirectory: The directory containing the component YAML files.
+//   - baselineFilePath: The path to the documentation data of a previous version,
+//     as exported by the `data` command with `--includeHidden`. If empty, rules that need a baseline are skipped.
+func (r *RealLinter) Lint(filesystem afero.Fs, componentDirectory string, baselineFilePath string) {
+	context := lintContext{Components: readComponentsFromDirectory(filesystem, componentDirectory)}
+
+	if baselineFilePath != "" {
+		baseline, err := readDocumentationData(filesystem, baselineFilePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if !baseline.IncludesHidden {
+			log.WithField("filePath", baselineFilePath).
+				Warn("The baseline does not include hidden elements. Export it via `labdoc data --includeHidden`")
+		}
+
+		context.Baseline = &baseline
+	}
+
+	reportLintIssues(lintComponents(context))
+
+	log.Info("No lint issues found!")
+}
+
+// lintComponents runs all lint rules.
+//
+// Parameters:
+//   - context: The data to check.
+//
+// Returns:
+//   - []LintIssue: The issues of all rules, sorted by component and rule.
+func lintComponents(context lintContext) []LintIssue {
+	issues := []LintIssue{}
+
+	for _, rule := range lintRules {
+		for _, issue := range rule.Check(context) {
+			issue.Rule = rule.Name
+			issues = append(issues, issue)
+		}
+	}
+
+	slices.SortStableFunc(issues, func(a, b LintIssue) int {
+		return cmp.Or(cmp.Compare(a.Component, b.Component), cmp.Compare(a.Rule, b.Rule))
+	})
+
+	return issues
+}
+
+// reportLintIssues logs every lint issue and exits if there is at least one.
+//
+// Parameters:
+//   - issues: The issues found by the lint rules.
+func reportLintIssues(issues []LintIssue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	for _, issue := range issues {
+		log.WithFields(log.Fields{
+			"component": issue.Component,
+			"rule":      issue.Rule,
+		}).Error(issue.Message)
+	}
+
+	log.WithField("issueCount", len(issues)).Fatal("Found lint issues")
+}
+
+// checkStableComponentRemovals reports stable components of the baseline that were removed without
+// being deprecated first. Components without a status are treated as stable.
+//
+// Parameters:
+//   - context: The data to check.
+//
+// Returns:
+//   - []LintIssue: An issue for every removed stable component.
+func checkStableComponentRemovals(context lintContext) []LintIssue {
+	issues := []LintIssue{}
+
+	if context.Baseline == nil {
+		return issues
+	}
+
+	for _, baselineComponent := range context.Baseline.Components {
+		componentExists := slices.ContainsFunc(context.Components, func(component Component) bool {
+			return component.Name == baselineComponent.Name
+		})
+		if componentExists || cmp.Or(baselineComponent.Annotations.Status, StatusStable) != StatusStable {
+			continue
+		}
+
+		issues = append(issues, LintIssue{
+			Component: baselineComponent.Name,
+			Message:   "stable component was removed without being deprecated first",
+		})
+	}
+
+	return issues
+}
+
+// checkStableInputRemovals reports stable inputs of the baseline that were removed without
+// being deprecated first. Inputs without a status have the status of their component,
+// and components without a status are treated as stable.
+//
+// Parameters:
+//   - context: The data to check.
+//
+// Returns:
+//   - []LintIssue: An issue for every removed stable input.
+func checkStableInputRemovals(context lintContext) []LintIssue {
+	issues := []LintIssue{}
+
+	if context.Baseline == nil {
+		return issues
+	}
+
+	for _, baselineComponent := range context.Baseline.Components {
+		componentIndex := slices.IndexFunc(context.Components, func(component Component) bool {
+			return component.Name == baselineComponent.Name
+		})
+		if componentIndex == -1 {
+			continue
+		}
+
+		for _, baselineInput := range baselineComponent.Inputs {
+			if inputStatus(baselineComponent, baselineInput) != StatusStable {
+				continue
+			}
+
+			inputExists := slices.ContainsFunc(context.Components[componentIndex].Inputs, func(input Input) bool {
+				return input.Name == baselineInput.Name
+			})
+			if !inputExists {
+				issues = append(issues, LintIssue{
+					Component: baselineComponent.Name,
+					Message: fmt.Sprintf(
+						"stable input %q was removed without being deprecated first",
+						baselineInput.Name,
+					),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// checkInvalidInputs reports inputs that GitLab rejects when the component is included,
+// e.g. inputs whose default does not have the type of the input.
+//
+// Parameters:
+//   - context: The data to check.
+//
+// Returns:
+//   - []LintIssue: An issue for every violation.
+func checkInvalidInputs(context lintContext) []LintIssue {
+	issues := []LintIssue{}
+
+	for _, component := range context.Components {
+		for _, input := range component.Inputs {
+			for _, err := range validateInput(input) {
+				issues = append(issues, LintIssue{Component: component.Name, Message: err.Error()})
+			}
+		}
+	}
+
+	return issues
+}
+
+// inputStatus returns the status of an input. Inputs without a status have the status
+// of their component, and components without a status are treated as stable.
+//
+// Parameters:
+//   - component: The component of the input.
+//   - input: The input.
+//
+// Returns:
+//   - string: The status of the input.
+func inputStatus(component Component, input Input) string {
+	return cmp.Or(input.Annotations.Status, component.Annotations.Status, StatusStable)
+}
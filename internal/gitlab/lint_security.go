@@ -0,0 +1,170 @@
+package gitlab
+
+import (
+	"cmp"
+	"fmt"
+	"regexp/syntax"
+	"slices"
+	"strings"
+)
+
+// injectionMetacharacters are the characters that allow changing the meaning of a shell
+// command or a `rules:if` expression when they are interpolated.
+const injectionMetacharacters = " \t\r\n;&|$`()<>\"'\\*?[]{}!#~="
+
+// checkUnsafeInterpolations returns a lint check that reports string inputs that are interpolated
+// into one of the job keywords without being restricted via `options` or a restrictive `regex`.
+// Consumers of the component can inject commands or conditions via such inputs.
+// Interpolations of hidden jobs that are resolved into extending jobs are reported once.
+// Interpolations of included files name the file, as their positions are positions in that file.
+// The keywords are also checked in `default` and at the top level, as they apply to all jobs there.
+//
+// Parameters:
+//   - keywords: The job keywords to check, e.g. "script" or "rules:if".
+//
+// Returns:
+//   - func(lintContext) []LintIssue: The lint check.
+func checkUnsafeInterpolations(keywords ...string) func(context lintContext) []LintIssue {
+	return func(context lintContext) []LintIssue {
+		issues := []LintIssue{}
+
+		for _, component := range context.Components {
+			type reportedInterpolation struct {
+				job           string
+				interpolation InputInterpolation
+			}
+
+			reported := []reportedInterpolation{}
+
+			// The global keywords are checked as a job without a name.
+			globalKeywords := Job{InputInterpolations: component.InputInterpolations}
+
+			for _, job := range slices.Concat([]Job{globalKeywords}, component.HiddenJobs, component.Jobs) {
+				for _, interpolation := range job.InputInterpolations {
+					alreadyReported := slices.ContainsFunc(reported, func(other reportedInterpolation) bool {
+						return other.interpolation == interpolation
+					})
+
+					keyword := strings.TrimPrefix(interpolation.Keyword, "default:")
+					if alreadyReported || !slices.Contains(keywords, keyword) {
+						continue
+					}
+
+					inputIndex := slices.IndexFunc(component.Inputs, func(input Input) bool {
+						return input.Name == interpolation.Input
+					})
+					if inputIndex == -1 || !isUnrestrictedStringInput(component.Inputs[inputIndex]) {
+						continue
+					}
+
+					reported = append(reported, reportedInterpolation{job: job.Name, interpolation: interpolation})
+				}
+			}
+
+			slices.SortStableFunc(reported, func(a, b reportedInterpolation) int {
+				return cmp.Or(
+					cmp.Compare(a.interpolation.Source, b.interpolation.Source),
+					cmp.Compare(a.interpolation.Line, b.interpolation.Line),
+					cmp.Compare(a.interpolation.Column, b.interpolation.Column),
+				)
+			})
+
+			for _, report := range reported {
+				location := "at the top level"
+				if report.job != "" {
+					location = fmt.Sprintf("of job %q", report.job)
+				} else if strings.HasPrefix(report.interpolation.Keyword, "default:") {
+					location = "of `default`"
+				}
+
+				message := fmt.Sprintf(
+					"string input %q without options or a restrictive regex is interpolated into `%s` "+
+						"%s at line %d, column %d",
+					report.interpolation.Input,
+					strings.TrimPrefix(report.interpolation.Keyword, "default:"),
+					location,
+					report.interpolation.Line,
+					report.interpolation.Column,
+				)
+				if report.interpolation.Source != "" {
+					message += fmt.Sprintf(" of included file `%s`", report.interpolation.Source)
+				}
+
+				issues = append(issues, LintIssue{Component: component.Name, Message: message})
+			}
+		}
+
+		return issues
+	}
+}
+
+// isUnrestrictedStringInput checks if an input accepts arbitrary strings.
+//
+// Parameters:
+//   - input: The input to check.
+//
+// Returns:
+//   - bool: True if the input is a string input without `options` and without a restrictive `regex`.
+func isUnrestrictedStringInput(input Input) bool {
+	if input.Type != "" && input.Type != InputTypeString {
+		return false
+	}
+
+	return len(input.Options) == 0 && !isRestrictiveRegex(input.Regex)
+}
+
+// isRestrictiveRegex checks if a regex only accepts values without injection metacharacters.
+// The regex must be anchored with `^` and `$` and must not match any of the metacharacters,
+// e.g. `^[a-z0-9-]+$`.
+//
+// Parameters:
+//   - regex: The regex to check.
+//
+// Returns:
+//   - bool: True if the regex is restrictive.
+func isRestrictiveRegex(regex string) bool {
+	parsedRegex, err := syntax.Parse(regex, syntax.Perl)
+	if err != nil {
+		return false
+	}
+
+	parsedRegex = parsedRegex.Simplify()
+	if parsedRegex.Op != syntax.OpConcat || len(parsedRegex.Sub) < 2 { //nolint:mnd
+		return false
+	}
+
+	first, last := parsedRegex.Sub[0], parsedRegex.Sub[len(parsedRegex.Sub)-1]
+	if first.Op != syntax.OpBeginText || last.Op != syntax.OpEndText {
+		return false
+	}
+
+	return !matchesInjectionMetacharacter(parsedRegex)
+}
+
+// matchesInjectionMetacharacter checks if a parsed regex can match an injection metacharacter.
+//
+// Parameters:
+//   - parsedRegex: The parsed regex.
+//
+// Returns:
+//   - bool: True if the regex can match at least one metacharacter.
+func matchesInjectionMetacharacter(parsedRegex *syntax.Regexp) bool {
+	switch parsedRegex.Op {
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return true
+	case syntax.OpLiteral:
+		return strings.ContainsAny(string(parsedRegex.Rune), injectionMetacharacters)
+	case syntax.OpCharClass:
+		for i := 0; i+1 < len(parsedRegex.Rune); i += 2 {
+			for _, metacharacter := range injectionMetacharacters {
+				if metacharacter >= parsedRegex.Rune[i] && metacharacter <= parsedRegex.Rune[i+1] {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	return slices.ContainsFunc(parsedRegex.Sub, matchesInjectionMetacharacter)
+}
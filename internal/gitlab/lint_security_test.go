@@ -0,0 +1,104 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUnsafeInterpolationsReportsUnrestrictedStringInputs(t *testing.T) {
+	t.Parallel()
+
+	interpolation := InputInterpolation{Input: "message", Keyword: "script", Line: 3, Column: 12}
+	context := lintContext{Components: []Component{{
+		Name: "component",
+		Inputs: []Input{
+			{Name: "message"},
+			{Name: "environment", Options: []interface{}{"dev", "prod"}},
+			{Name: "count", Type: InputTypeNumber},
+		},
+		HiddenJobs: []Job{{Name: ".template", InputInterpolations: []InputInterpolation{interpolation}}},
+		Jobs: []Job{{
+			Name: "job",
+			InputInterpolations: []InputInterpolation{
+				{Input: "message", Keyword: "rules:if", Line: 8, Column: 10},
+				interpolation,
+				{Input: "environment", Keyword: "script", Line: 9, Column: 5},
+				{Input: "count", Keyword: "script", Line: 10, Column: 5},
+				{Input: "undefined", Keyword: "script", Line: 11, Column: 5},
+				{Input: "message", Keyword: "script", Line: 2, Column: 5},
+			},
+		}, {
+			Name: "included",
+			InputInterpolations: []InputInterpolation{
+				{Input: "message", Keyword: "script", Line: 3, Column: 12, Source: "templates/jobs.yml"},
+			},
+		}},
+	}}}
+
+	issues := checkUnsafeInterpolations("script")(context)
+	assert.Equal(t, []LintIssue{
+		{
+			Component: "component",
+			Message: "string input \"message\" without options or a restrictive regex is interpolated into " +
+				"`script` of job \"job\" at line 2, column 5",
+		},
+		{
+			Component: "component",
+			Message: "string input \"message\" without options or a restrictive regex is interpolated into " +
+				"`script` of job \".template\" at line 3, column 12",
+		},
+		{
+			Component: "component",
+			Message: "string input \"message\" without options or a restrictive regex is interpolated into " +
+				"`script` of job \"included\" at line 3, column 12 of included file `templates/jobs.yml`",
+		},
+	}, issues)
+}
+
+func TestCheckUnsafeInterpolationsReportsDefaultAndTopLevelKeywords(t *testing.T) {
+	t.Parallel()
+
+	context := lintContext{Components: []Component{{
+		Name:   "component",
+		Inputs: []Input{{Name: "image"}, {Name: "target"}},
+		InputInterpolations: []InputInterpolation{
+			{Input: "image", Keyword: "default:image", Line: 2, Column: 11},
+			{Input: "target", Keyword: "default:before_script", Line: 4, Column: 13},
+			{Input: "target", Keyword: "after_script", Line: 8, Column: 13},
+		},
+	}}}
+
+	assert.Equal(t, []LintIssue{{
+		Component: "component",
+		Message: "string input \"image\" without options or a restrictive regex is interpolated into " +
+			"`image` of `default` at line 2, column 11",
+	}}, checkUnsafeInterpolations("image")(context))
+	assert.Equal(t, []LintIssue{
+		{
+			Component: "component",
+			Message: "string input \"target\" without options or a restrictive regex is interpolated into " +
+				"`before_script` of `default` at line 4, column 13",
+		},
+		{
+			Component: "component",
+			Message: "string input \"target\" without options or a restrictive regex is interpolated into " +
+				"`after_script` at the top level at line 8, column 13",
+		},
+	}, checkUnsafeInterpolations("script", "before_script", "after_script")(context))
+}
+
+func TestIsRestrictiveRegexRequiresAnchorsAndSafeCharacters(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isRestrictiveRegex(`^[a-z0-9.-]+$`))
+	assert.True(t, isRestrictiveRegex(`^v\d+\.\d+$`))
+	assert.True(t, isRestrictiveRegex(`^(dev|prod)$`))
+	assert.False(t, isRestrictiveRegex(``))
+	assert.False(t, isRestrictiveRegex(`[a-z]+`))
+	assert.False(t, isRestrictiveRegex(`^.+$`))
+	assert.False(t, isRestrictiveRegex(`^[^;]+$`))
+	assert.False(t, isRestrictiveRegex(`^[a-z ]+$`))
+	assert.False(t, isRestrictiveRegex(`^a$|^b;c$`))
+	assert.False(t, isRestrictiveRegex(`(`))
+}
@@ -0,0 +1,165 @@
+package gitlab
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStableInputRemovalsReportsRemovedStableInputs(t *testing.T) {
+	t.Parallel()
+
+	baseline := ComponentsDocumentation{
+		Components: []Component{
+			{
+				Name: "component",
+				Inputs: []Input{
+					{Name: "kept"},
+					{Name: "removed-stable"},
+					{Name: "removed-deprecated", Annotations: Annotations{Status: StatusDeprecated}},
+					{Name: "removed-beta", Annotations: Annotations{Status: StatusBeta}},
+				},
+			},
+			{
+				Name:   "experimental-component",
+				Inputs: []Input{{Name: "removed"}},
+				Annotations: Annotations{
+					Status: StatusExperimental,
+				},
+			},
+			{Name: "removed-component", Inputs: []Input{{Name: "input"}}},
+		},
+	}
+
+	context := lintContext{
+		Components: []Component{
+			{Name: "component", Inputs: []Input{{Name: "kept"}}},
+			{Name: "experimental-component"},
+		},
+		Baseline: &baseline,
+	}
+
+	issues := checkStableInputRemovals(context)
+	assert.Equal(t, []LintIssue{{
+		Component: "component",
+		Message:   "stable input \"removed-stable\" was removed without being deprecated first",
+	}}, issues)
+}
+
+func TestCheckStableComponentRemovalsReportsRemovedStableComponents(t *testing.T) {
+	t.Parallel()
+
+	baseline := ComponentsDocumentation{
+		Components: []Component{
+			{Name: "kept"},
+			{Name: "removed-stable"},
+			{Name: "removed-explicitly-stable", Annotations: Annotations{Status: StatusStable}},
+			{Name: "removed-deprecated", Annotations: Annotations{Status: StatusDeprecated}},
+			{Name: "removed-beta", Annotations: Annotations{Status: StatusBeta}},
+		},
+	}
+
+	context := lintContext{Components: []Component{{Name: "kept"}}, Baseline: &baseline}
+
+	issues := lintComponents(context)
+	assert.Equal(t, []LintIssue{
+		{
+			Rule:      "stable-component-removed",
+			Component: "removed-explicitly-stable",
+			Message:   "stable component was removed without being deprecated first",
+		},
+		{
+			Rule:      "stable-component-removed",
+			Component: "removed-stable",
+			Message:   "stable component was removed without being deprecated first",
+		},
+	}, issues)
+}
+
+func TestCheckStableInputRemovalsReportsRemovedHiddenInputsOfUnfilteredBaseline(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(`spec:
+  inputs:
+    stage: {}
+    # labdoc:hidden
+    debug-mode:
+      default: false
+`), 0o644)
+	require.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	dataExporter := &RealDocumentationDataExporter{}
+	dataExporter.ExportDocumentationData(
+		filesystem, buffer, "templates", "gitlab.com/group/project", "1.0.0", "json", ".labdoc.yml", true,
+	)
+	err = afero.WriteFile(filesystem, "baseline.json", buffer.Bytes(), 0o644)
+	require.NoError(t, err)
+
+	baseline, err := readDocumentationData(filesystem, "baseline.json")
+	require.NoError(t, err)
+	assert.True(t, baseline.IncludesHidden)
+
+	context := lintContext{
+		Components: []Component{{Name: "component", Inputs: []Input{{Name: "stage"}}}},
+		Baseline:   &baseline,
+	}
+
+	assert.Equal(t, []LintIssue{{
+		Component: "component",
+		Message:   "stable input \"debug-mode\" was removed without being deprecated first",
+	}}, checkStableInputRemovals(context))
+}
+
+func TestCheckStableInputRemovalsSkipsWithoutBaseline(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, checkStableInputRemovals(lintContext{Components: []Component{{Name: "component"}}}))
+}
+
+func TestLintIssueStringContainsComponentAndRule(t *testing.T) {
+	t.Parallel()
+
+	issue := LintIssue{Rule: "rule", Component: "component", Message: "message"}
+	assert.Equal(t, "component: message (rule)", issue.String())
+}
+
+func TestLintReadsComponentsAndBaseline(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte("spec:\n  inputs:\n    stage: {}\n"), 0o644)
+	require.NoError(t, err)
+	err = afero.WriteFile(
+		filesystem,
+		"baseline.json",
+		[]byte(`{"Components": [{"Name": "component", "Inputs": [{"Name": "stage"}]}]}`),
+		0o644,
+	)
+	require.NoError(t, err)
+
+	linter := &RealLinter{}
+	linter.Lint(filesystem, "templates", "baseline.json")
+}
+
+func TestCheckInvalidInputsReportsInvalidDefaults(t *testing.T) {
+	t.Parallel()
+
+	context := lintContext{Components: []Component{{
+		Name: "component",
+		Inputs: []Input{
+			{Name: "valid", Type: InputTypeNumber, Default: 1},
+			{Name: "invalid", Type: InputTypeNumber, Default: "one"},
+		},
+	}}}
+
+	assert.Equal(t, []LintIssue{{
+		Rule:      "invalid-input",
+		Component: "component",
+		Message:   `default "one" of input "invalid" is not of type "number"`,
+	}}, lintComponents(context))
+}
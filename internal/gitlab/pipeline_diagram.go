@@ -0,0 +1,157 @@
+package gitlab
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// defaultStage is the stage of jobs that do not set the `stage` keyword.
+const defaultStage = "test"
+
+// defaultStages are the stages GitLab uses if the `stages` keyword is not set.
+var defaultStages = []string{"build", "test", "deploy"}
+
+// buildPipelineDiagram creates a Mermaid flowchart of the jobs. The jobs are grouped
+// by stage, needs are drawn as solid arrows and dependencies as dotted arrows.
+// Hidden jobs are not part of the diagram, since they are never run.
+//
+// Parameters:
+//   - jobs: The jobs of the component.
+//   - stages: The stages defined via the `stages` keyword of the component.
+//
+// Returns:
+//   - string: The Mermaid flowchart, or an empty string if there are no jobs to draw.
+func buildPipelineDiagram(jobs []Job, stages []string) string {
+	visibleJobs := []Job{}
+
+	for _, job := range jobs {
+		if !job.Hidden && !isHiddenJobName(job.Name) {
+			visibleJobs = append(visibleJobs, job)
+		}
+	}
+
+	if len(visibleJobs) == 0 {
+		return ""
+	}
+
+	nodeIDs := map[string]string{}
+	for index, job := range visibleJobs {
+		nodeIDs[job.Name] = fmt.Sprintf("job%d", index)
+	}
+
+	lines := []string{"flowchart LR"}
+
+	for stageIndex, stage := range orderStages(visibleJobs, stages) {
+		lines = append(lines, fmt.Sprintf("  subgraph stage%d[%s]", stageIndex, mermaidLabel(stage)))
+
+		for _, job := range visibleJobs {
+			if jobStage(job) == stage {
+				lines = append(
+					lines,
+					fmt.Sprintf("    %s[%s]", nodeIDs[job.Name], mermaidLabel(cmp.Or(job.ResolvedName, job.Name))),
+				)
+			}
+		}
+
+		lines = append(lines, "  end")
+	}
+
+	externalNodeCount := 0
+
+	for _, job := range visibleJobs {
+		for _, edge := range []struct {
+			sources []string
+			arrow   string
+		}{
+			{job.Needs, "-->"},
+			{job.Dependencies, "-.->"},
+		} {
+			for _, source := range edge.sources {
+				sourceID, exists := nodeIDs[source]
+				if !exists {
+					// Jobs outside of the component, e.g. from the including pipeline.
+					sourceID = fmt.Sprintf("external%d", externalNodeCount)
+					nodeIDs[source] = sourceID
+					externalNodeCount++
+
+					lines = append(lines, fmt.Sprintf("  %s([%s])", sourceID, mermaidLabel(source)))
+				}
+
+				lines = append(lines, fmt.Sprintf("  %s %s %s", sourceID, edge.arrow, nodeIDs[job.Name]))
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// orderStages returns the stages used by the jobs in pipeline order. Stages defined
+// via the `stages` keyword come first, followed by the default stages of GitLab and
+// all remaining stages in alphabetical order. `.pre` and `.post` are always first and last.
+//
+// Parameters:
+//   - jobs: The jobs of the component.
+//   - stages: The stages defined via the `stages` keyword of the component.
+//
+// Returns:
+//   - []string: The stages used by the jobs, in pipeline order.
+func orderStages(jobs []Job, stages []string) []string {
+	usedStages := []string{}
+
+	for _, job := range jobs {
+		if !slices.Contains(usedStages, jobStage(job)) {
+			usedStages = append(usedStages, jobStage(job))
+		}
+	}
+
+	slices.Sort(usedStages)
+
+	knownStages := slices.Concat([]string{".pre"}, stages, defaultStages)
+	orderedStages := []string{}
+
+	for _, stage := range knownStages {
+		if slices.Contains(usedStages, stage) && !slices.Contains(orderedStages, stage) && stage != ".post" {
+			orderedStages = append(orderedStages, stage)
+		}
+	}
+
+	for _, stage := range usedStages {
+		if !slices.Contains(orderedStages, stage) && stage != ".post" {
+			orderedStages = append(orderedStages, stage)
+		}
+	}
+
+	if slices.Contains(usedStages, ".post") {
+		orderedStages = append(orderedStages, ".post")
+	}
+
+	return orderedStages
+}
+
+// jobStage returns the stage in which a job runs.
+//
+// Parameters:
+//   - job: The job.
+//
+// Returns:
+//   - string: The stage of the job, or the default stage if the job does not set one.
+func jobStage(job Job) string {
+	if job.Stage == "" {
+		return defaultStage
+	}
+
+	return job.Stage
+}
+
+// mermaidLabel quotes a text so that it can be used as label of a Mermaid node.
+//
+// Parameters:
+//   - text: The text of the label.
+//
+// Returns:
+//   - string: The quoted label.
+func mermaidLabel(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, "#quot;") + `"`
+}
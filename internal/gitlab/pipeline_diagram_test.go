@@ -0,0 +1,74 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPipelineDiagramGroupsJobsByStageAndConnectsNeeds(t *testing.T) {
+	t.Parallel()
+
+	jobs := []Job{
+		{Name: "build", Stage: "build"},
+		{Name: "deploy", Stage: "deploy", Needs: []string{"test"}, Dependencies: []string{"build"}},
+		{Name: "test", Needs: []string{"build", "lint"}},
+		{Name: ".hidden", Stage: "build"},
+	}
+
+	expectedDiagram := `flowchart LR
+  subgraph stage0["build"]
+    job0["build"]
+  end
+  subgraph stage1["test"]
+    job2["test"]
+  end
+  subgraph stage2["deploy"]
+    job1["deploy"]
+  end
+  job2 --> job1
+  job0 -.-> job1
+  job0 --> job2
+  external0(["lint"])
+  external0 --> job2
+`
+
+	assert.Equal(t, expectedDiagram, buildPipelineDiagram(jobs, nil))
+}
+
+func TestBuildPipelineDiagramLabelsJobsWithResolvedNames(t *testing.T) {
+	t.Parallel()
+
+	jobs := []Job{{Name: "$[[ inputs.prefix ]]-build", ResolvedName: "docs-build", Stage: "build"}}
+
+	assert.Contains(t, buildPipelineDiagram(jobs, nil), `    job0["docs-build"]`)
+}
+
+func TestBuildPipelineDiagramReturnsEmptyStringWithoutVisibleJobs(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, buildPipelineDiagram([]Job{{Name: ".hidden"}}, nil))
+}
+
+func TestOrderStagesUsesDefinedStagesAndKeepsPreAndPostAtTheEdges(t *testing.T) {
+	t.Parallel()
+
+	jobs := []Job{
+		{Name: "a", Stage: ".post"},
+		{Name: "b", Stage: "zeta"},
+		{Name: "c", Stage: "alpha"},
+		{Name: "d", Stage: "deploy"},
+		{Name: "e", Stage: "verify"},
+		{Name: "f", Stage: ".pre"},
+	}
+
+	expectedStages := []string{".pre", "verify", "deploy", "alpha", "zeta", ".post"}
+
+	assert.Equal(t, expectedStages, orderStages(jobs, []string{"verify"}))
+}
+
+func TestMermaidLabelEscapesQuotes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"say #quot;hi#quot;"`, mermaidLabel(`say "hi"`))
+}
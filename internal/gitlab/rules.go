@@ -0,0 +1,388 @@
+package gitlab
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is an entry of the `rules` keyword of a job or of `workflow:rules`.
+type Rule struct {
+	// If is the expression of `rules:if`.
+	If string
+	// Changes are the paths of `rules:changes`.
+	Changes []string
+	// Exists are the paths of `rules:exists`.
+	Exists []string
+	// When is the value of `rules:when`. Empty if the rule does not set it.
+	When string
+}
+
+// RefFilter is the value of the deprecated `only` or `except` keyword of a job.
+type RefFilter struct {
+	// Refs are the refs, e.g. "main" or "tags".
+	Refs []string
+	// Variables are the expressions of `variables`.
+	Variables []string
+	// Changes are the paths of `changes`.
+	Changes []string
+}
+
+// knownConditions are readable descriptions of common `rules:if` conditions.
+var knownConditions = map[string]string{
+	`$CI_PIPELINE_SOURCE == "merge_request_event"`: "on merge requests",
+	`$CI_PIPELINE_SOURCE == "schedule"`:            "on scheduled pipelines",
+	`$CI_PIPELINE_SOURCE == "push"`:                "on pushes",
+	`$CI_PIPELINE_SOURCE == "web"`:                 "on pipelines started from the UI",
+	`$CI_PIPELINE_SOURCE == "api"`:                 "on pipelines started via the API",
+	`$CI_PIPELINE_SOURCE == "trigger"`:             "on triggered pipelines",
+	`$CI_PIPELINE_SOURCE == "parent_pipeline"`:     "in child pipelines",
+	`$CI_COMMIT_BRANCH == $CI_DEFAULT_BRANCH`:      "on the default branch",
+	`$CI_COMMIT_REF_NAME == $CI_DEFAULT_BRANCH`:    "on the default branch",
+	`$CI_COMMIT_BRANCH != $CI_DEFAULT_BRANCH`:      "on branches other than the default branch",
+	`$CI_COMMIT_TAG`:                               "on tags",
+	`$CI_COMMIT_BRANCH`:                            "on branches",
+	`$CI_MERGE_REQUEST_IID`:                        "on merge requests",
+	`$CI_OPEN_MERGE_REQUESTS`:                      "on branches with open merge requests",
+}
+
+// branchConditionPattern matches conditions on a single branch, like `$CI_COMMIT_BRANCH == "main"`.
+var branchConditionPattern = regexp.MustCompile(`^\$CI_COMMIT_(?:BRANCH|REF_NAME) == "([^"]+)"$`)
+
+// knownRefs are readable descriptions of the special refs of `only` and `except`.
+var knownRefs = map[string]string{
+	"branches":       "branches",
+	"tags":           "tags",
+	"merge_requests": "merge requests",
+	"schedules":      "scheduled pipelines",
+	"pushes":         "pushes",
+	"web":            "pipelines started from the UI",
+	"api":            "pipelines started via the API",
+	"triggers":       "triggered pipelines",
+}
+
+// jobRuleVerbs describe what a job does for each value of `when`.
+var jobRuleVerbs = map[string]string{
+	"":           "runs",
+	"on_success": "runs",
+	"manual":     "runs manually",
+	"delayed":    "runs delayed",
+	"always":     "always runs",
+	"never":      "never runs",
+	"on_failure": "runs on failure",
+}
+
+// workflowRuleVerbs describe what a workflow does for each value of `when`.
+var workflowRuleVerbs = map[string]string{
+	"":       "creates pipelines",
+	"always": "creates pipelines",
+	"never":  "creates no pipelines",
+}
+
+// parseRules parses the entries of a `rules` keyword.
+//
+// Parameters:
+//   - rulesNode: The YAML node of the `rules` keyword. Nil if the keyword is not set.
+//
+// Returns:
+//   - []Rule: The rules, in the order of their definition. Entries that are not mappings are skipped.
+//   - error: An error if a rule has an unexpected format.
+func parseRules(rulesNode *yaml.Node) ([]Rule, error) {
+	var rules []Rule
+
+	if rulesNode == nil || rulesNode.Kind != yaml.SequenceNode {
+		return rules, nil
+	}
+
+	for _, ruleNode := range rulesNode.Content {
+		if ruleNode.Kind != yaml.MappingNode {
+			continue
+		}
+
+		var ruleKeywords struct {
+			If      string    `yaml:"if"`
+			Changes yaml.Node `yaml:"changes"`
+			Exists  yaml.Node `yaml:"exists"`
+			When    string    `yaml:"when"`
+		}
+
+		if err := ruleNode.Decode(&ruleKeywords); err != nil {
+			return nil, fmt.Errorf("failed to parse rule: %w", err)
+		}
+
+		rules = append(rules, Rule{
+			If:      ruleKeywords.If,
+			Changes: parsePaths(&ruleKeywords.Changes),
+			Exists:  parsePaths(&ruleKeywords.Exists),
+			When:    ruleKeywords.When,
+		})
+	}
+
+	return rules, nil
+}
+
+// parseRefFilter parses the value of an `only` or `except` keyword, which is either
+// a list of refs or a mapping with `refs`, `variables` and `changes`.
+//
+// Parameters:
+//   - filterNode: The YAML node of the keyword. Nil if the keyword is not set.
+//
+// Returns:
+//   - *RefFilter: The parsed filter. Nil if the keyword is not set.
+func parseRefFilter(filterNode *yaml.Node) *RefFilter {
+	if filterNode == nil {
+		return nil
+	}
+
+	if filterNode.Kind != yaml.MappingNode {
+		return &RefFilter{Refs: parsePaths(filterNode)}
+	}
+
+	return &RefFilter{
+		Refs:      parsePaths(mappingValue(filterNode, "refs")),
+		Variables: parsePaths(mappingValue(filterNode, "variables")),
+		Changes:   parsePaths(mappingValue(filterNode, "changes")),
+	}
+}
+
+// parsePaths parses a list of strings, a single string, or a mapping with the list as `paths`,
+// like the value of `rules:changes`.
+//
+// Parameters:
+//   - node: The YAML node. Nil or empty nodes have no paths.
+//
+// Returns:
+//   - []string: The strings of the node.
+func parsePaths(node *yaml.Node) []string {
+	var paths []string
+
+	if node == nil {
+		return paths
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		return parsePaths(mappingValue(node, "paths"))
+	case yaml.ScalarNode:
+		if node.Value != "" {
+			paths = append(paths, node.Value)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				paths = append(paths, item.Value)
+			}
+		}
+	}
+
+	return paths
+}
+
+// summarizeJobRuns describes when a job runs, based on its `rules`, `only`, `except` and `when`.
+//
+// Parameters:
+//   - job: The job to describe.
+//
+// Returns:
+//   - string: A sentence like "Runs on merge requests; does not run otherwise.".
+//     Empty if the job runs in every pipeline.
+func summarizeJobRuns(job Job) string {
+	if len(job.Rules) > 0 {
+		return summarizeRules(job.Rules, jobRuleVerbs, job.When, "does not run otherwise")
+	}
+
+	verb := cmp.Or(jobRuleVerbs[job.When], jobRuleVerbs[""])
+	clauses := []string{}
+
+	// Empty filters, like `only: []`, do not restrict the job.
+	if job.Only != nil {
+		if description := describeRefFilter(*job.Only, " and "); description != "" {
+			clauses = append(clauses, description)
+		}
+	}
+
+	if job.Except != nil {
+		if description := describeRefFilter(*job.Except, " or "); description != "" {
+			clauses = append(clauses, "except "+description)
+		}
+	}
+
+	if len(clauses) == 0 {
+		if verb == jobRuleVerbs[""] {
+			return ""
+		}
+
+		return formatSentence(verb)
+	}
+
+	return formatSentence(verb + " " + strings.Join(clauses, ", "))
+}
+
+// summarizeWorkflowRules describes when pipelines are created, based on `workflow:rules`.
+//
+// Parameters:
+//   - rules: The workflow rules.
+//
+// Returns:
+//   - string: A sentence like "Creates pipelines on merge requests; creates no pipelines otherwise.".
+//     Empty if there are no rules.
+func summarizeWorkflowRules(rules []Rule) string {
+	if len(rules) == 0 {
+		return ""
+	}
+
+	return summarizeRules(rules, workflowRuleVerbs, "", "creates no pipelines otherwise")
+}
+
+// summarizeRules describes a list of rules. Rules are evaluated in order, so rules after
+// the first rule without conditions are ignored.
+//
+// Parameters:
+//   - rules: The rules to describe.
+//   - verbs: The description of each value of `when`.
+//   - defaultWhen: The `when` of rules that do not set it.
+//   - otherwise: The description of what happens if no rule matches.
+//
+// Returns:
+//   - string: A sentence with one clause per rule.
+func summarizeRules(rules []Rule, verbs map[string]string, defaultWhen string, otherwise string) string {
+	clauses := []string{}
+
+	for index, rule := range rules {
+		verb := cmp.Or(verbs[cmp.Or(rule.When, defaultWhen)], verbs[""])
+		conditions := describeRuleConditions(rule)
+
+		if conditions == "" {
+			if index > 0 {
+				verb += " in all other cases"
+			}
+
+			return formatSentence(strings.Join(append(clauses, verb), "; "))
+		}
+
+		clauses = append(clauses, verb+" "+conditions)
+	}
+
+	return formatSentence(strings.Join(append(clauses, otherwise), "; "))
+}
+
+// describeRuleConditions describes the `if`, `changes` and `exists` conditions of a rule.
+//
+// Parameters:
+//   - rule: The rule to describe.
+//
+// Returns:
+//   - string: The conditions, e.g. "on merge requests when files in `src/**` change".
+//     Empty if the rule has no conditions.
+func describeRuleConditions(rule Rule) string {
+	conditions := []string{}
+
+	if rule.If != "" {
+		conditions = append(conditions, describeExpression(rule.If))
+	}
+
+	if len(rule.Changes) > 0 {
+		conditions = append(conditions, "when files in "+formatCodeList(rule.Changes)+" change")
+	}
+
+	if len(rule.Exists) > 0 {
+		conditions = append(conditions, "when "+formatCodeList(rule.Exists)+" exists")
+	}
+
+	return strings.Join(conditions, " ")
+}
+
+// describeRefFilter describes an `only` or `except` filter.
+//
+// Parameters:
+//   - filter: The filter to describe.
+//   - separator: The separator of refs and expressions, e.g. " and ".
+//
+// Returns:
+//   - string: The description, e.g. "on tags and `main`".
+func describeRefFilter(filter RefFilter, separator string) string {
+	descriptions := []string{}
+
+	if len(filter.Refs) > 0 {
+		refs := []string{}
+
+		for _, ref := range filter.Refs {
+			refs = append(refs, cmp.Or(knownRefs[ref], "`"+ref+"`"))
+		}
+
+		descriptions = append(descriptions, "on "+strings.Join(refs, separator))
+	}
+
+	for _, expression := range filter.Variables {
+		descriptions = append(descriptions, describeExpression(expression))
+	}
+
+	if len(filter.Changes) > 0 {
+		descriptions = append(descriptions, "when files in "+formatCodeList(filter.Changes)+" change")
+	}
+
+	return strings.Join(descriptions, separator)
+}
+
+// describeExpression describes a CI/CD expression. Common conditions joined by `&&` are described
+// in words, all other expressions are quoted as written. Single quotes are only treated like double
+// quotes when recognizing the common conditions.
+//
+// Parameters:
+//   - expression: The expression, e.g. `$CI_PIPELINE_SOURCE == "merge_request_event"`.
+//
+// Returns:
+//   - string: The description, e.g. "on merge requests".
+func describeExpression(expression string) string {
+	collapsedExpression := strings.Join(strings.Fields(expression), " ")
+	if strings.Contains(collapsedExpression, "||") || strings.Contains(collapsedExpression, "(") {
+		return "if `" + strings.TrimSpace(expression) + "`"
+	}
+
+	descriptions := []string{}
+
+	for _, condition := range strings.Split(collapsedExpression, "&&") {
+		condition = strings.TrimSpace(condition)
+		normalizedCondition := strings.ReplaceAll(condition, "'", `"`)
+
+		switch match := branchConditionPattern.FindStringSubmatch(normalizedCondition); {
+		case knownConditions[normalizedCondition] != "":
+			descriptions = append(descriptions, knownConditions[normalizedCondition])
+		case match != nil:
+			descriptions = append(descriptions, "on branch `"+match[1]+"`")
+		default:
+			descriptions = append(descriptions, "if `"+condition+"`")
+		}
+	}
+
+	return strings.Join(descriptions, " and ")
+}
+
+// formatCodeList formats values as comma-separated inline code.
+//
+// Parameters:
+//   - values: The values to format.
+//
+// Returns:
+//   - string: The formatted values, e.g. "`a`, `b`".
+func formatCodeList(values []string) string {
+	return "`" + strings.Join(values, "`, `") + "`"
+}
+
+// formatSentence capitalizes the first letter of a text and ends it with a period.
+//
+// Parameters:
+//   - text: The text to format.
+//
+// Returns:
+//   - string: The sentence.
+func formatSentence(text string) string {
+	firstRune, size := utf8.DecodeRuneInString(text)
+
+	return string(unicode.ToUpper(firstRune)) + text[size:] + "."
+}
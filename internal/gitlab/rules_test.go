@@ -0,0 +1,112 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalYAMLParsesRulesOnlyExceptAndWhen(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `workflow:
+  rules:
+    - if: $CI_COMMIT_TAG
+      when: never
+    - when: always
+test:
+  rules:
+    - if: $CI_PIPELINE_SOURCE == "merge_request_event"
+      changes:
+        paths: ["src/**"]
+    - exists: "Dockerfile"
+      when: manual
+legacy:
+  only:
+    refs: ["main"]
+    changes: ["docs/**"]
+  except: ["tags"]
+  when: manual
+`
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Rule{{If: "$CI_COMMIT_TAG", When: "never"}, {When: "always"}}, gitlabCiConfig.WorkflowRules)
+	assert.Equal(t, []Rule{
+		{If: `$CI_PIPELINE_SOURCE == "merge_request_event"`, Changes: []string{"src/**"}},
+		{Exists: []string{"Dockerfile"}, When: "manual"},
+	}, gitlabCiConfig.Jobs[0].Rules)
+	assert.Equal(t, &RefFilter{Refs: []string{"main"}, Changes: []string{"docs/**"}}, gitlabCiConfig.Jobs[1].Only)
+	assert.Equal(t, &RefFilter{Refs: []string{"tags"}}, gitlabCiConfig.Jobs[1].Except)
+	assert.Equal(t, "manual", gitlabCiConfig.Jobs[1].When)
+	assert.Equal(t, "Runs manually on `main` and when files in `docs/**` change, except on tags.",
+		gitlabCiConfig.Jobs[1].RunSummary)
+}
+
+func TestSummarizeJobRunsDescribesRules(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		job             Job
+		expectedSummary string
+	}{
+		{Job{}, ""},
+		{Job{When: "on_success"}, ""},
+		{Job{When: "manual"}, "Runs manually."},
+		{
+			Job{Rules: []Rule{
+				{If: `$CI_PIPELINE_SOURCE == "merge_request_event"`, Changes: []string{"src/**"}},
+				{If: `$CI_COMMIT_BRANCH == $CI_DEFAULT_BRANCH`, When: "manual"},
+			}},
+			"Runs on merge requests when files in `src/**` change; runs manually on the default branch; " +
+				"does not run otherwise.",
+		},
+		{
+			Job{When: "manual", Rules: []Rule{{If: `$CI_COMMIT_TAG`, When: "never"}, {}}},
+			"Never runs on tags; runs manually in all other cases.",
+		},
+		{
+			Job{Rules: []Rule{{When: "always"}, {If: "$CI_COMMIT_TAG"}}},
+			"Always runs.",
+		},
+		{
+			Job{Rules: []Rule{{If: `$CI_COMMIT_BRANCH == 'release' && $DEPLOY`, Exists: []string{"Chart.yaml"}}}},
+			"Runs on branch `release` and if `$DEPLOY` when `Chart.yaml` exists; does not run otherwise.",
+		},
+		{
+			Job{Rules: []Rule{{If: `$A == "1" || $B`}}},
+			"Runs if `$A == \"1\" || $B`; does not run otherwise.",
+		},
+		{
+			Job{Rules: []Rule{{If: `$CI_PIPELINE_SOURCE == 'push' && $MESSAGE =~ /it's/`}}},
+			"Runs on pushes and if `$MESSAGE =~ /it's/`; does not run otherwise.",
+		},
+		{
+			Job{Rules: []Rule{{If: `$A == 'x' || $B`}}},
+			"Runs if `$A == 'x' || $B`; does not run otherwise.",
+		},
+		{Job{Only: &RefFilter{}}, ""},
+		{Job{Only: &RefFilter{}, Except: &RefFilter{Refs: []string{"tags"}}}, "Runs except on tags."},
+		{Job{When: "manual", Except: &RefFilter{}}, "Runs manually."},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expectedSummary, summarizeJobRuns(testCase.job))
+	}
+}
+
+func TestSummarizeWorkflowRulesDescribesPipelineCreation(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, summarizeWorkflowRules(nil))
+	assert.Equal(
+		t,
+		"Creates no pipelines on scheduled pipelines; creates pipelines in all other cases.",
+		summarizeWorkflowRules([]Rule{{If: `$CI_PIPELINE_SOURCE == "schedule"`, When: "never"}, {When: "always"}}),
+	)
+}
@@ -0,0 +1,98 @@
+package gitlab
+
+import (
+	"strings"
+
+	"github.com/erNail/labdoc/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// JobScript is the value of one of the script keywords of a job.
+type JobScript struct {
+	// Keyword is the script keyword, e.g. "before_script".
+	Keyword string
+	// Lines are the lines of all commands of the keyword.
+	Lines []string
+	// OmittedLines is the number of lines removed from the end, because the script exceeds the maximum length.
+	OmittedLines int
+}
+
+// Fence returns the fence of the Markdown code block of the script. It is one backtick longer than
+// the longest run of backticks in the script, so that the script can not close the code block.
+//
+// Returns:
+//   - string: The fence, at least "```".
+func (script JobScript) Fence() string {
+	longestRun := 0
+
+	for _, line := range script.Lines {
+		run := 0
+
+		for _, character := range line {
+			if character != '`' {
+				run = 0
+
+				continue
+			}
+
+			run++
+			longestRun = max(longestRun, run)
+		}
+	}
+
+	return strings.Repeat("`", max(3, longestRun+1)) //nolint:mnd
+}
+
+// parseJobScripts parses the `before_script`, `script` and `after_script` keywords of a job.
+//
+// Parameters:
+//   - jobNode: The mapping node of the effective job configuration.
+//
+// Returns:
+//   - []JobScript: The scripts, in the order in which they are run. Keywords without commands are skipped.
+func parseJobScripts(jobNode *yaml.Node) []JobScript {
+	var scripts []JobScript
+
+	for _, keyword := range []string{"before_script", "script", "after_script"} {
+		script := JobScript{Keyword: keyword}
+
+		for _, command := range parsePaths(flattenSequence(mappingValue(jobNode, keyword))) {
+			script.Lines = append(script.Lines, strings.Split(strings.TrimRight(command, "\n"), "\n")...)
+		}
+
+		if len(script.Lines) > 0 {
+			scripts = append(scripts, script)
+		}
+	}
+
+	return scripts
+}
+
+// applyScriptsConfig decides for each job if its scripts are shown and truncates the scripts
+// to the maximum length. The `@script` annotation of a job takes precedence over the configuration.
+//
+// Parameters:
+//   - jobs: The jobs to update.
+//   - scriptsConfig: The scripts configuration.
+func applyScriptsConfig(jobs []Job, scriptsConfig config.ScriptsConfig) {
+	for index := range jobs {
+		job := &jobs[index]
+
+		switch job.Annotations.Script {
+		case ScriptShow:
+			job.ShowScripts = true
+		case ScriptHide:
+			job.ShowScripts = false
+		default:
+			job.ShowScripts = scriptsConfig.Show
+		}
+
+		for scriptIndex := range job.Scripts {
+			script := &job.Scripts[scriptIndex]
+			if scriptsConfig.MaxLines > 0 && len(script.Lines) > scriptsConfig.MaxLines {
+				script.OmittedLines += len(script.Lines) - scriptsConfig.MaxLines
+				script.Lines = script.Lines[:scriptsConfig.MaxLines]
+			}
+		}
+	}
+}
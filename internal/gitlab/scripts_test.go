@@ -0,0 +1,114 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/erNail/labdoc/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalYAMLParsesJobScripts(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `.setup:
+  script:
+    - apk add make
+build:
+  after_script: "echo done"
+  before_script:
+    - !reference [.setup, script]
+  script:
+    - |
+      make build
+      make test
+    - echo "built"
+test:
+  stage: test
+`
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, []JobScript{
+		{Keyword: "before_script", Lines: []string{"apk add make"}},
+		{Keyword: "script", Lines: []string{"make build", "make test", "echo \"built\""}},
+		{Keyword: "after_script", Lines: []string{"echo done"}},
+	}, gitlabCiConfig.Jobs[0].Scripts)
+	assert.Nil(t, gitlabCiConfig.Jobs[1].Scripts)
+}
+
+func TestUnmarshalYAMLKeepsUnresolvedReferencesInJobScripts(t *testing.T) {
+	t.Parallel()
+
+	yamlFileContent := `build:
+  before_script: !reference [.nope, script]
+  script:
+    - echo "start"
+    - !reference [.nope, script]
+  artifacts:
+    reports:
+      dotenv: "build.env"
+`
+
+	var gitlabCiConfig CiConfig
+
+	err := yaml.Unmarshal([]byte(yamlFileContent), &gitlabCiConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, []JobScript{
+		{Keyword: "before_script", Lines: []string{"!reference [.nope, script]"}},
+		{Keyword: "script", Lines: []string{"echo \"start\"", "!reference [.nope, script]"}},
+	}, gitlabCiConfig.Jobs[0].Scripts)
+	assert.Empty(t, gitlabCiConfig.Jobs[0].Artifacts.DotenvVariables)
+}
+
+func TestApplyScriptsConfigPrefersAnnotation(t *testing.T) {
+	t.Parallel()
+
+	jobs := []Job{
+		{Name: "default"},
+		{Name: "shown", Annotations: Annotations{Script: ScriptShow}},
+		{Name: "hidden", Annotations: Annotations{Script: ScriptHide}},
+	}
+
+	applyScriptsConfig(jobs, config.ScriptsConfig{Show: false, MaxLines: 10})
+	assert.False(t, jobs[0].ShowScripts)
+	assert.True(t, jobs[1].ShowScripts)
+	assert.False(t, jobs[2].ShowScripts)
+
+	applyScriptsConfig(jobs, config.ScriptsConfig{Show: true, MaxLines: 10})
+	assert.True(t, jobs[0].ShowScripts)
+	assert.True(t, jobs[1].ShowScripts)
+	assert.False(t, jobs[2].ShowScripts)
+}
+
+func TestApplyScriptsConfigTruncatesLongScripts(t *testing.T) {
+	t.Parallel()
+
+	jobs := []Job{{
+		Name: "job",
+		Scripts: []JobScript{
+			{Keyword: "before_script", Lines: []string{"a", "b"}},
+			{Keyword: "script", Lines: []string{"a", "b", "c", "d", "e"}},
+		},
+	}}
+
+	applyScriptsConfig(jobs, config.ScriptsConfig{MaxLines: 2})
+
+	assert.Equal(t, []JobScript{
+		{Keyword: "before_script", Lines: []string{"a", "b"}},
+		{Keyword: "script", Lines: []string{"a", "b"}, OmittedLines: 3},
+	}, jobs[0].Scripts)
+}
+
+func TestJobScriptFenceIsLongerThanBackticksInScript(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "```", JobScript{Lines: []string{"echo `date`"}}.Fence())
+	assert.Equal(t, "````", JobScript{Lines: []string{"cat <<EOF", "```yaml", "EOF"}}.Fence())
+	assert.Equal(t, "``````", JobScript{Lines: []string{"echo '`````'", "echo '``'"}}.Fence())
+}
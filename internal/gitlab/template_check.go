@@ -0,0 +1,562 @@
+package gitlab
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// TemplateFieldError represents a reference to a field that does not exist
+// on the data passed to a documentation template.
+type TemplateFieldError struct {
+	Line  int
+	Field string
+	Type  string
+}
+
+// Error returns a human-readable description of the TemplateFieldError.
+func (e TemplateFieldError) Error() string {
+	return fmt.Sprintf("line %d: can't evaluate field %s in type %s", e.Line, e.Field, e.Type)
+}
+
+// TemplateChecker defines the interface for statically checking documentation templates.
+type TemplateChecker interface {
+	CheckTemplate(filesystem afero.Fs, templateFilePath string)
+}
+
+// RealTemplateChecker implements the TemplateChecker interface.
+type RealTemplateChecker struct{}
+
+// CheckTemplate checks that every field referenced in the template exists on
+// the ComponentsDocumentation type. Each unknown field is logged with its line number.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - templateFilePath: The path to the template file to check.
+func (r *RealTemplateChecker) CheckTemplate(filesystem afero.Fs, templateFilePath string) {
+	templateFileContent := readTemplateFile(templateFilePath, filesystem)
+
+	fieldErrors, err := checkTemplateFields(templateFilePath, templateFileContent)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	reportTemplateFieldErrors(fieldErrors)
+
+	log.Info("Your template is valid!")
+}
+
+// reportTemplateFieldErrors logs every unknown field and exits if there is at least one.
+//
+// Parameters:
+//   - fieldErrors: The unknown fields referenced by a template.
+func reportTemplateFieldErrors(fieldErrors []TemplateFieldError) {
+	if len(fieldErrors) == 0 {
+		return
+	}
+
+	for _, fieldError := range fieldErrors {
+		log.WithFields(log.Fields{
+			"line":  fieldError.Line,
+			"field": fieldError.Field,
+			"type":  fieldError.Type,
+		}).Error("Unknown field in template")
+	}
+
+	log.WithField("errorCount", len(fieldErrors)).Fatal("Template references unknown fields")
+}
+
+// checkTemplateFields parses the template and walks its parse tree against the
+// ComponentsDocumentation type, collecting references to unknown fields.
+//
+// Parameters:
+//   - templateName: The name of the template, used for parsing.
+//   - templateContent: The content of the template.
+//
+// Returns:
+//   - []TemplateFieldError: The unknown fields referenced by the template.
+//   - error: An error if the template can not be parsed.
+func checkTemplateFields(templateName string, templateContent string) ([]TemplateFieldError, error) {
+	tmpl, err := template.New(templateName).Parse(templateContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	rootType := reflect.TypeOf(ComponentsDocumentation{})
+	checker := &templateTreeChecker{
+		tmpl:    tmpl,
+		visited: map[string]bool{},
+	}
+	checker.checkTree(tmpl.Tree, rootType, map[string]reflect.Type{"$": rootType})
+
+	return checker.errors, nil
+}
+
+// templateTreeChecker walks template parse trees while tracking the type of dot and variables.
+// A nil reflect.Type means the type can not be determined statically, in which case no
+// checks are done.
+type templateTreeChecker struct {
+	tmpl    *template.Template
+	visited map[string]bool
+	errors  []TemplateFieldError
+}
+
+// checkTree checks a single parse tree with the given type of dot.
+//
+// Parameters:
+//   - tree: The parse tree to check.
+//   - dot: The type of dot at the start of the tree.
+//   - variables: The types of the variables in scope.
+func (c *templateTreeChecker) checkTree(tree *parse.Tree, dot reflect.Type, variables map[string]reflect.Type) {
+	if tree == nil || tree.Root == nil {
+		return
+	}
+
+	c.checkNode(tree, tree.Root, dot, variables)
+}
+
+// checkNode checks a node of a parse tree and all of its children.
+//
+// Parameters:
+//   - tree: The parse tree the node belongs to.
+//   - node: The node to check.
+//   - dot: The type of dot at the node.
+//   - variables: The types of the variables in scope.
+func (c *templateTreeChecker) checkNode(
+	tree *parse.Tree,
+	node parse.Node,
+	dot reflect.Type,
+	variables map[string]reflect.Type,
+) {
+	switch typedNode := node.(type) {
+	case *parse.ListNode:
+		if typedNode == nil {
+			return
+		}
+
+		for _, child := range typedNode.Nodes {
+			c.checkNode(tree, child, dot, variables)
+		}
+	case *parse.ActionNode:
+		c.checkPipe(tree, typedNode.Pipe, dot, variables)
+	case *parse.IfNode:
+		c.checkBranch(tree, &typedNode.BranchNode, dot, variables)
+	case *parse.WithNode:
+		c.checkBranch(tree, &typedNode.BranchNode, dot, variables)
+	case *parse.RangeNode:
+		c.checkBranch(tree, &typedNode.BranchNode, dot, variables)
+	case *parse.TemplateNode:
+		c.checkTemplateInvocation(tree, typedNode, dot, variables)
+	}
+}
+
+// checkBranch checks an if, with or range node.
+//
+// Parameters:
+//   - tree: The parse tree the node belongs to.
+//   - branch: The branch node to check.
+//   - dot: The type of dot at the node.
+//   - variables: The types of the variables in scope.
+func (c *templateTreeChecker) checkBranch(
+	tree *parse.Tree,
+	branch *parse.BranchNode,
+	dot reflect.Type,
+	variables map[string]reflect.Type,
+) {
+	scopedVariables := copyVariableTypes(variables)
+	pipeType := c.evaluatePipe(tree, branch.Pipe, dot, scopedVariables)
+
+	innerDot := dot
+
+	switch branch.NodeType {
+	case parse.NodeWith:
+		innerDot = pipeType
+		c.declareVariables(branch.Pipe, scopedVariables, pipeType)
+	case parse.NodeRange:
+		keyType, elemType := rangeTypes(pipeType)
+		innerDot = elemType
+
+		if len(branch.Pipe.Decl) == 1 {
+			scopedVariables[branch.Pipe.Decl[0].Ident[0]] = elemType
+		} else if len(branch.Pipe.Decl) == 2 { //nolint:mnd
+			scopedVariables[branch.Pipe.Decl[0].Ident[0]] = keyType
+			scopedVariables[branch.Pipe.Decl[1].Ident[0]] = elemType
+		}
+	default:
+		c.declareVariables(branch.Pipe, scopedVariables, pipeType)
+	}
+
+	c.checkNode(tree, branch.List, innerDot, scopedVariables)
+
+	if branch.ElseList != nil {
+		c.checkNode(tree, branch.ElseList, dot, copyVariableTypes(variables))
+	}
+}
+
+// checkTemplateInvocation checks a named template with the type of the data passed to it.
+//
+// Parameters:
+//   - tree: The parse tree the invocation belongs to.
+//   - node: The template invocation node.
+//   - dot: The type of dot at the invocation.
+//   - variables: The types of the variables in scope.
+func (c *templateTreeChecker) checkTemplateInvocation(
+	tree *parse.Tree,
+	node *parse.TemplateNode,
+	dot reflect.Type,
+	variables map[string]reflect.Type,
+) {
+	var invocationType reflect.Type
+	if node.Pipe != nil {
+		invocationType = c.evaluatePipe(tree, node.Pipe, dot, variables)
+	}
+
+	visitedKey := fmt.Sprintf("%s/%v", node.Name, invocationType)
+	if c.visited[visitedKey] {
+		return
+	}
+
+	c.visited[visitedKey] = true
+
+	namedTemplate := c.tmpl.Lookup(node.Name)
+	if namedTemplate == nil {
+		return
+	}
+
+	c.checkTree(namedTemplate.Tree, invocationType, map[string]reflect.Type{"$": invocationType})
+}
+
+// checkPipe evaluates a pipeline and declares its variables.
+//
+// Parameters:
+//   - tree: The parse tree the pipeline belongs to.
+//   - pipe: The pipeline to check.
+//   - dot: The type of dot at the pipeline.
+//   - variables: The types of the variables in scope.
+func (c *templateTreeChecker) checkPipe(
+	tree *parse.Tree,
+	pipe *parse.PipeNode,
+	dot reflect.Type,
+	variables map[string]reflect.Type,
+) {
+	pipeType := c.evaluatePipe(tree, pipe, dot, variables)
+	c.declareVariables(pipe, variables, pipeType)
+}
+
+// declareVariables declares or assigns the variables of a pipeline.
+//
+// Parameters:
+//   - pipe: The pipeline declaring the variables.
+//   - variables: The types of the variables in scope.
+//   - pipeType: The type the pipeline evaluates to.
+func (c *templateTreeChecker) declareVariables(
+	pipe *parse.PipeNode,
+	variables map[string]reflect.Type,
+	pipeType reflect.Type,
+) {
+	for _, variable := range pipe.Decl {
+		name := variable.Ident[0]
+
+		// Reassigning a variable with a different type makes its type unknown.
+		if pipe.IsAssign {
+			if existingType, exists := variables[name]; exists && existingType != pipeType {
+				variables[name] = nil
+
+				continue
+			}
+		}
+
+		variables[name] = pipeType
+	}
+}
+
+// evaluatePipe returns the type a pipeline evaluates to and checks all fields used in it.
+//
+// Parameters:
+//   - tree: The parse tree the pipeline belongs to.
+//   - pipe: The pipeline to evaluate.
+//   - dot: The type of dot at the pipeline.
+//   - variables: The types of the variables in scope.
+//
+// Returns:
+//   - reflect.Type: The type of the pipeline, or nil if it is unknown.
+func (c *templateTreeChecker) evaluatePipe(
+	tree *parse.Tree,
+	pipe *parse.PipeNode,
+	dot reflect.Type,
+	variables map[string]reflect.Type,
+) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+
+	var pipeType reflect.Type
+
+	for _, command := range pipe.Cmds {
+		pipeType = c.evaluateCommand(tree, command, dot, variables)
+	}
+
+	return pipeType
+}
+
+// evaluateCommand returns the type a command evaluates to and checks all fields used in it.
+//
+// Parameters:
+//   - tree: The parse tree the command belongs to.
+//   - command: The command to evaluate.
+//   - dot: The type of dot at the command.
+//   - variables: The types of the variables in scope.
+//
+// Returns:
+//   - reflect.Type: The type of the command, or nil if it is unknown.
+func (c *templateTreeChecker) evaluateCommand(
+	tree *parse.Tree,
+	command *parse.CommandNode,
+	dot reflect.Type,
+	variables map[string]reflect.Type,
+) reflect.Type {
+	var commandType reflect.Type
+
+	for i, arg := range command.Args {
+		argType := c.evaluateArg(tree, arg, dot, variables)
+		if i == 0 {
+			commandType = argType
+		}
+	}
+
+	if len(command.Args) > 0 {
+		if _, isFunction := command.Args[0].(*parse.IdentifierNode); isFunction {
+			return nil
+		}
+	}
+
+	return commandType
+}
+
+// evaluateArg returns the type an argument evaluates to and checks all fields used in it.
+//
+// Parameters:
+//   - tree: The parse tree the argument belongs to.
+//   - arg: The argument to evaluate.
+//   - dot: The type of dot at the argument.
+//   - variables: The types of the variables in scope.
+//
+// Returns:
+//   - reflect.Type: The type of the argument, or nil if it is unknown.
+func (c *templateTreeChecker) evaluateArg(
+	tree *parse.Tree,
+	arg parse.Node,
+	dot reflect.Type,
+	variables map[string]reflect.Type,
+) reflect.Type {
+	switch typedArg := arg.(type) {
+	case *parse.DotNode:
+		return dot
+	case *parse.FieldNode:
+		return c.resolveFields(tree, arg, dot, typedArg.Ident)
+	case *parse.VariableNode:
+		variableType, exists := variables[typedArg.Ident[0]]
+		if !exists {
+			return nil
+		}
+
+		return c.resolveFields(tree, arg, variableType, typedArg.Ident[1:])
+	case *parse.ChainNode:
+		nodeType := c.evaluateArg(tree, typedArg.Node, dot, variables)
+
+		return c.resolveFields(tree, arg, nodeType, typedArg.Field)
+	case *parse.PipeNode:
+		return c.evaluatePipe(tree, typedArg, dot, copyVariableTypes(variables))
+	case *parse.StringNode:
+		return reflect.TypeOf("")
+	case *parse.BoolNode:
+		return reflect.TypeOf(true)
+	default:
+		return nil
+	}
+}
+
+// resolveFields follows a chain of field names starting at the given type and
+// records an error for the first field that does not exist.
+//
+// Parameters:
+//   - tree: The parse tree the node belongs to.
+//   - node: The node referencing the fields, used for the error location.
+//   - startType: The type on which the field chain starts.
+//   - fieldNames: The chain of field names.
+//
+// Returns:
+//   - reflect.Type: The type of the last field, or nil if it is unknown.
+func (c *templateTreeChecker) resolveFields(
+	tree *parse.Tree,
+	node parse.Node,
+	startType reflect.Type,
+	fieldNames []string,
+) reflect.Type {
+	currentType := startType
+
+	for _, fieldName := range fieldNames {
+		if currentType == nil {
+			return nil
+		}
+
+		nextType, found := lookupFieldType(currentType, fieldName)
+		if !found {
+			c.errors = append(c.errors, TemplateFieldError{
+				Line:  nodeLine(tree, node),
+				Field: fieldName,
+				Type:  currentType.String(),
+			})
+
+			return nil
+		}
+
+		currentType = nextType
+	}
+
+	return currentType
+}
+
+// lookupFieldType returns the type of a field, method or map key on the given type.
+//
+// Parameters:
+//   - parentType: The type on which the field is looked up.
+//   - fieldName: The name of the field.
+//
+// Returns:
+//   - reflect.Type: The type of the field, or nil if it can not be determined.
+//   - bool: False if the field definitely does not exist.
+func lookupFieldType(parentType reflect.Type, fieldName string) (reflect.Type, bool) {
+	if method, exists := parentType.MethodByName(fieldName); exists {
+		return methodResultType(method.Type), true
+	}
+
+	baseType := parentType
+	for baseType.Kind() == reflect.Pointer {
+		baseType = baseType.Elem()
+	}
+
+	if method, exists := reflect.PointerTo(baseType).MethodByName(fieldName); exists {
+		return methodResultType(method.Type), true
+	}
+
+	switch baseType.Kind() {
+	case reflect.Struct:
+		field, exists := baseType.FieldByName(fieldName)
+		if !exists || !field.IsExported() {
+			return nil, false
+		}
+
+		return knownType(field.Type), true
+	case reflect.Map:
+		return knownType(baseType.Elem()), true
+	case reflect.Interface:
+		return nil, true
+	default:
+		return nil, false
+	}
+}
+
+// methodResultType returns the type of the first result of a method.
+//
+// Parameters:
+//   - methodType: The type of the method.
+//
+// Returns:
+//   - reflect.Type: The type of the first result, or nil if there is none.
+func methodResultType(methodType reflect.Type) reflect.Type {
+	if methodType.NumOut() == 0 {
+		return nil
+	}
+
+	return knownType(methodType.Out(0))
+}
+
+// knownType returns nil for interface types, since their dynamic type is unknown.
+//
+// Parameters:
+//   - valueType: The static type of a value.
+//
+// Returns:
+//   - reflect.Type: The type, or nil if it is an interface type.
+func knownType(valueType reflect.Type) reflect.Type {
+	if valueType.Kind() == reflect.Interface {
+		return nil
+	}
+
+	return valueType
+}
+
+// rangeTypes returns the key and element types when ranging over the given type.
+//
+// Parameters:
+//   - rangedType: The type that is ranged over.
+//
+// Returns:
+//   - reflect.Type: The type of the key or index.
+//   - reflect.Type: The type of the element.
+func rangeTypes(rangedType reflect.Type) (reflect.Type, reflect.Type) {
+	if rangedType == nil {
+		return nil, nil
+	}
+
+	for rangedType.Kind() == reflect.Pointer {
+		rangedType = rangedType.Elem()
+	}
+
+	switch rangedType.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeOf(0), knownType(rangedType.Elem())
+	case reflect.Map:
+		return knownType(rangedType.Key()), knownType(rangedType.Elem())
+	default:
+		return nil, nil
+	}
+}
+
+// nodeLine returns the line number of a node within its template.
+//
+// Parameters:
+//   - tree: The parse tree the node belongs to.
+//   - node: The node to locate.
+//
+// Returns:
+//   - int: The line number of the node, or 0 if it can not be determined.
+func nodeLine(tree *parse.Tree, node parse.Node) int {
+	location, _ := tree.ErrorContext(node)
+	locationParts := strings.Split(location, ":")
+
+	if len(locationParts) < 3 { //nolint:mnd
+		return 0
+	}
+
+	line, err := strconv.Atoi(locationParts[len(locationParts)-2])
+	if err != nil {
+		return 0
+	}
+
+	return line
+}
+
+// copyVariableTypes creates a copy of the variable types, so that variables
+// declared in an inner scope do not leak into the outer scope.
+//
+// Parameters:
+//   - variables: The types of the variables in scope.
+//
+// Returns:
+//   - map[string]reflect.Type: A copy of the variable types.
+func copyVariableTypes(variables map[string]reflect.Type) map[string]reflect.Type {
+	variablesCopy := make(map[string]reflect.Type, len(variables))
+	for name, variableType := range variables {
+		variablesCopy[name] = variableType
+	}
+
+	return variablesCopy
+}
@@ -0,0 +1,83 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTemplateFieldsAcceptsDefaultTemplate(t *testing.T) {
+	t.Parallel()
+
+	templateContent := readTemplateFile("resources/default-template.md.gotmpl", afero.NewMemMapFs())
+
+	fieldErrors, err := checkTemplateFields("default", templateContent)
+
+	require.NoError(t, err)
+	assert.Empty(t, fieldErrors)
+}
+
+func TestCheckTemplateFieldsReportsUnknownFieldsWithLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	templateContent := `# {{ .RepoUrl }}
+{{- range $component := .Components }}
+{{ $component.Descripton }}
+{{- range .Inputs }}
+{{ .Name }} {{ .Mandatory }}
+{{- end }}
+{{- end }}
+`
+
+	expectedFieldErrors := []TemplateFieldError{
+		{Line: 1, Field: "RepoUrl", Type: "gitlab.ComponentsDocumentation"},
+		{Line: 3, Field: "Descripton", Type: "gitlab.Component"},
+		{Line: 5, Field: "Mandatory", Type: "gitlab.Input"},
+	}
+
+	fieldErrors, err := checkTemplateFields("custom", templateContent)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedFieldErrors, fieldErrors)
+}
+
+func TestCheckTemplateFieldsFollowsWithAndNamedTemplates(t *testing.T) {
+	t.Parallel()
+
+	templateContent := `{{ define "component" }}{{ .Nmae }}{{ end }}
+{{- with index .Components 0 }}{{ .Unknown }}{{ end }}
+{{- range .Components }}{{ template "component" . }}{{ end }}
+{{- with .Components }}{{ len . }}{{ else }}{{ .Versoin }}{{ end }}
+`
+
+	expectedFieldErrors := []TemplateFieldError{
+		{Line: 1, Field: "Nmae", Type: "gitlab.Component"},
+		{Line: 4, Field: "Versoin", Type: "gitlab.ComponentsDocumentation"},
+	}
+
+	fieldErrors, err := checkTemplateFields("custom", templateContent)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedFieldErrors, fieldErrors)
+}
+
+func TestCheckTemplateFieldsSkipsValuesOfUnknownType(t *testing.T) {
+	t.Parallel()
+
+	templateContent := `{{ range .Components }}{{ range .Inputs }}{{ .Default.Anything }}{{ end }}{{ end }}`
+
+	fieldErrors, err := checkTemplateFields("custom", templateContent)
+
+	require.NoError(t, err)
+	assert.Empty(t, fieldErrors)
+}
+
+func TestCheckTemplateFieldsReturnsErrorOnInvalidTemplate(t *testing.T) {
+	t.Parallel()
+
+	_, err := checkTemplateFields("custom", "{{ range .Components }}")
+
+	require.Error(t, err)
+}
@@ -0,0 +1,221 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/erNail/labdoc/internal/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// DocumentationDataExporter defines the interface for exporting the data that is passed to templates.
+type DocumentationDataExporter interface {
+	ExportDocumentationData(
+		filesystem afero.Fs,
+		writer io.Writer,
+		componentDirectory string,
+		repoURL string,
+		componentVersion string,
+		format string,
+		configFilePath string,
+		includeHidden bool)
+}
+
+// RealDocumentationDataExporter implements the DocumentationDataExporter interface.
+type RealDocumentationDataExporter struct{}
+
+// ExportDocumentationData builds the ComponentsDocumentation for the components in the
+// specified directory and writes it in the given format.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - writer: The writer to which the data is written.
+//   - componentDirectory: The directory containing the component YAML files.
+//   - repoURL: The URL of the repository containing the components.
+//   - componentVersion: The version or ref of the components to document.
+//   - format: The format of the data. Either "json" or "yaml".
+//   - configFilePath: The path to the labdoc configuration file. Defaults are used if it does not exist.
+//   - includeHidden: If true, elements hidden from the documentation and components of all statuses are included.
+func (r *RealDocumentationDataExporter) ExportDocumentationData(
+	filesystem afero.Fs,
+	writer io.Writer,
+	componentDirectory string,
+	repoURL string,
+	componentVersion string,
+	format string,
+	configFilePath string,
+	includeHidden bool,
+) {
+	configuration, err := config.LoadConfig(filesystem, configFilePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	components := readComponentsFromDirectory(filesystem, componentDirectory)
+
+	var componentsDocumentation ComponentsDocumentation
+	if includeHidden {
+		componentsDocumentation = buildUnfilteredComponentDocumentation(
+			components, repoURL, componentVersion, configuration,
+		)
+		componentsDocumentation.IncludesHidden = true
+	} else {
+		componentsDocumentation = buildComponentDocumentationFromComponents(
+			components, repoURL, componentVersion, configuration,
+		)
+	}
+
+	data, err := marshalDocumentationData(componentsDocumentation, format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = writer.Write(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TemplateRenderer defines the interface for rendering a template from a data fixture.
+type TemplateRenderer interface {
+	RenderTemplate(
+		filesystem afero.Fs,
+		writer io.Writer,
+		dataFilePath string,
+		templateFilePath string,
+		outputFilePath string,
+		strict bool)
+}
+
+// RealTemplateRenderer implements the TemplateRenderer interface.
+type RealTemplateRenderer struct{}
+
+// RenderTemplate renders a template against a data fixture, without reading any components.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - writer: The writer to which the rendered content is written if no output file is given.
+//   - dataFilePath: The path to the JSON or YAML file containing the documentation data.
+//   - templateFilePath: The path to the template file used for rendering.
+//   - outputFilePath: The path where the rendered content will be saved. If empty, the writer is used.
+//   - strict: If true, the template is checked for unknown fields and missing map keys cause an error.
+func (r *RealTemplateRenderer) RenderTemplate(
+	filesystem afero.Fs,
+	writer io.Writer,
+	dataFilePath string,
+	templateFilePath string,
+	outputFilePath string,
+	strict bool,
+) {
+	componentsDocumentation, err := readDocumentationData(filesystem, dataFilePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	documentationContent := renderDocumentationContent(componentsDocumentation, templateFilePath, filesystem, strict)
+
+	if outputFilePath != "" {
+		writeDocumentation(filesystem, outputFilePath, documentationContent)
+
+		return
+	}
+
+	_, err = io.WriteString(writer, documentationContent)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// marshalDocumentationData serializes the documentation data. The field names
+// are kept identical to the ones used in templates.
+//
+// Parameters:
+//   - componentsDocumentation: The data to serialize.
+//   - format: The format of the data. Either "json" or "yaml".
+//
+// Returns:
+//   - []byte: The serialized data.
+//   - error: An error if the format is unknown or serialization fails.
+func marshalDocumentationData(componentsDocumentation ComponentsDocumentation, format string) ([]byte, error) {
+	jsonData, err := json.MarshalIndent(componentsDocumentation, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize documentation data: %w", err)
+	}
+
+	switch format {
+	case "json":
+		return append(jsonData, '\n'), nil
+	case "yaml":
+		// The YAML tags of the types are used for parsing components, so the
+		// data is converted via JSON to keep the field names used in templates.
+		var genericData interface{}
+
+		err = json.Unmarshal(jsonData, &genericData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize documentation data: %w", err)
+		}
+
+		buffer := new(bytes.Buffer)
+		encoder := yaml.NewEncoder(buffer)
+		encoder.SetIndent(2) //nolint:mnd
+
+		err = encoder.Encode(genericData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize documentation data: %w", err)
+		}
+
+		return buffer.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown data format %q. use \"json\" or \"yaml\"", format)
+	}
+}
+
+// readDocumentationData reads documentation data from a JSON or YAML file,
+// as written by marshalDocumentationData.
+//
+// Parameters:
+//   - filesystem: An interface for interacting with the file system.
+//   - dataFilePath: The path to the data file. Files ending in ".yml" or ".yaml" are read as YAML.
+//
+// Returns:
+//   - ComponentsDocumentation: The documentation data.
+//   - error: An error if the file can not be read or parsed.
+func readDocumentationData(filesystem afero.Fs, dataFilePath string) (ComponentsDocumentation, error) {
+	var componentsDocumentation ComponentsDocumentation
+
+	data, err := afero.ReadFile(filesystem, dataFilePath)
+	if err != nil {
+		return componentsDocumentation, fmt.Errorf("failed to read documentation data: %w", err)
+	}
+
+	extension := filepath.Ext(dataFilePath)
+	if extension == ".yml" || extension == ".yaml" {
+		var genericData interface{}
+
+		err = yaml.Unmarshal(data, &genericData)
+		if err != nil {
+			return componentsDocumentation, fmt.Errorf("failed to parse documentation data: %w", err)
+		}
+
+		data, err = json.Marshal(genericData)
+		if err != nil {
+			return componentsDocumentation, fmt.Errorf("failed to parse documentation data: %w", err)
+		}
+	}
+
+	// Numbers are kept as written, so that large integers like input defaults are not rendered as floats.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&componentsDocumentation)
+	if err != nil {
+		return componentsDocumentation, fmt.Errorf("failed to parse documentation data: %w", err)
+	}
+
+	return componentsDocumentation, nil
+}
@@ -0,0 +1,179 @@
+package gitlab
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportDocumentationDataWritesJSON(t *testing.T) {
+	t.Parallel()
+
+	componentContent := `---
+# Component description
+spec:
+  inputs:
+    stage:
+      default: "test"
+...
+---
+# Job comment
+job: {}
+`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "templates/component.yml", []byte(componentContent), 0o644)
+	require.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	dataExporter := &RealDocumentationDataExporter{}
+	dataExporter.ExportDocumentationData(
+		filesystem,
+		buffer,
+		"templates",
+		"github.com/test",
+		"1.0.0",
+		"json",
+		".labdoc.yml",
+		false,
+	)
+
+	assert.Contains(t, buffer.String(), `"RepoURL": "github.com/test"`)
+	assert.Contains(t, buffer.String(), `"Description": "Component description"`)
+	assert.Contains(t, buffer.String(), `"Name": "stage"`)
+}
+
+func TestMarshalDocumentationDataKeepsTemplateFieldNamesInYAML(t *testing.T) {
+	t.Parallel()
+
+	componentsDocumentation := ComponentsDocumentation{
+		RepoURL: "github.com/test",
+		Version: "1.0.0",
+		Components: []Component{
+			{Name: "component", Inputs: []Input{{Name: "stage"}}},
+		},
+	}
+
+	data, err := marshalDocumentationData(componentsDocumentation, "yaml")
+
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "RepoURL: github.com/test")
+	assert.Contains(t, string(data), "Name: stage")
+}
+
+func TestMarshalDocumentationDataReturnsErrorOnUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := marshalDocumentationData(ComponentsDocumentation{}, "xml")
+
+	require.Error(t, err)
+}
+
+func TestReadDocumentationDataReadsWhatWasMarshalled(t *testing.T) {
+	t.Parallel()
+
+	componentsDocumentation := ComponentsDocumentation{
+		RepoURL: "github.com/test",
+		Version: "1.0.0",
+		Components: []Component{
+			{
+				Name:        "component",
+				Description: "Component description",
+				Inputs:      []Input{{Name: "stage", Type: "string", Default: "test"}},
+				Jobs:        []Job{{Name: "job", Comment: "Job comment"}},
+			},
+		},
+	}
+
+	filesystem := afero.NewMemMapFs()
+
+	for _, format := range []string{"json", "yaml"} {
+		data, err := marshalDocumentationData(componentsDocumentation, format)
+		require.NoError(t, err)
+
+		dataFilePath := "fixture." + format
+		err = afero.WriteFile(filesystem, dataFilePath, data, 0o644)
+		require.NoError(t, err)
+
+		actualDocumentation, err := readDocumentationData(filesystem, dataFilePath)
+		require.NoError(t, err)
+		assert.Equal(t, componentsDocumentation, actualDocumentation)
+	}
+}
+
+func TestRenderTemplateKeepsLargeIntegersOfFixture(t *testing.T) {
+	t.Parallel()
+
+	componentsDocumentation := ComponentsDocumentation{
+		Components: []Component{{
+			Name:   "component",
+			Inputs: []Input{{Name: "size", Type: "number", Default: 1000000, Options: []interface{}{1000000, 2}}},
+		}},
+	}
+	templateContent := `{{ range .Components }}{{ range .Inputs }}{{ .Default }} {{ .Options }}{{ end }}{{ end }}`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "template.md.gotmpl", []byte(templateContent), 0o644)
+	require.NoError(t, err)
+
+	for _, format := range []string{"json", "yaml"} {
+		data, err := marshalDocumentationData(componentsDocumentation, format)
+		require.NoError(t, err)
+
+		dataFilePath := "fixture." + format
+		err = afero.WriteFile(filesystem, dataFilePath, data, 0o644)
+		require.NoError(t, err)
+
+		buffer := new(bytes.Buffer)
+		templateRenderer := &RealTemplateRenderer{}
+		templateRenderer.RenderTemplate(filesystem, buffer, dataFilePath, "template.md.gotmpl", "", false)
+
+		assert.Equal(t, "1000000 [1000000 2]", buffer.String())
+	}
+}
+
+func TestRenderTemplateRendersFixtureWithoutComponents(t *testing.T) {
+	t.Parallel()
+
+	fixtureContent := `{"RepoURL": "github.com/test", "Version": "1.0.0", "Components": [{"Name": "component"}]}`
+	templateContent := `{{ range .Components }}{{ $.RepoURL }}/{{ .Name }}@{{ $.Version }}{{ end }}`
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "fixture.json", []byte(fixtureContent), 0o644)
+	require.NoError(t, err)
+	err = afero.WriteFile(filesystem, "template.md.gotmpl", []byte(templateContent), 0o644)
+	require.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	templateRenderer := &RealTemplateRenderer{}
+	templateRenderer.RenderTemplate(filesystem, buffer, "fixture.json", "template.md.gotmpl", "", false)
+
+	assert.Equal(t, "github.com/test/component@1.0.0", buffer.String())
+}
+
+func TestRenderTemplateWritesOutputFile(t *testing.T) {
+	t.Parallel()
+
+	filesystem := afero.NewMemMapFs()
+	err := afero.WriteFile(filesystem, "fixture.json", []byte(`{"Version": "1.0.0"}`), 0o644)
+	require.NoError(t, err)
+	err = afero.WriteFile(filesystem, "template.md.gotmpl", []byte(`{{ .Version }}`), 0o644)
+	require.NoError(t, err)
+
+	templateRenderer := &RealTemplateRenderer{}
+	templateRenderer.RenderTemplate(
+		filesystem,
+		new(bytes.Buffer),
+		"fixture.json",
+		"template.md.gotmpl",
+		"out.md",
+		false,
+	)
+
+	outputContent, err := afero.ReadFile(filesystem, "out.md")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", string(outputContent))
+}
@@ -0,0 +1,242 @@
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/erNail/labdoc/internal/config"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// UsageVariant represents one way of including a component, e.g. with a pinned version or with `~latest`.
+type UsageVariant struct {
+	Title            string
+	Reference        string
+	UsageExample     string
+	FullUsageExample string
+}
+
+// buildUsageVariants creates a usage variant of the component for each configured reference.
+//
+// Parameters:
+//   - component: The component to include. The inputs are expected to be sorted.
+//   - repoURL: The URL of the repository containing the components.
+//   - version: The version or ref of the components to document.
+//   - references: The configured references.
+//
+// Returns:
+//   - []UsageVariant: A usage variant for each configured reference.
+func buildUsageVariants(
+	component Component,
+	repoURL string,
+	version string,
+	references []config.ReferenceConfig,
+) []UsageVariant {
+	usageVariants := []UsageVariant{}
+
+	for _, reference := range references {
+		componentReference := buildComponentReference(repoURL, component.Name, version, reference)
+		usageVariants = append(usageVariants, UsageVariant{
+			Title:            reference.Title,
+			Reference:        componentReference,
+			UsageExample:     buildUsageExample(component, componentReference, false),
+			FullUsageExample: buildUsageExample(component, componentReference, true),
+		})
+	}
+
+	return usageVariants
+}
+
+// buildComponentReference creates the reference used in the `component` keyword of an include.
+// Variables in the host and ref, like "$CI_COMMIT_SHA", are kept as written, so that the
+// documentation does not change with the environment it is generated in.
+//
+// Parameters:
+//   - repoURL: The URL of the repository containing the components.
+//   - componentName: The name of the component.
+//   - version: The version or ref of the components to document.
+//   - reference: The configured reference.
+//
+// Returns:
+//   - string: The component reference, e.g. "gitlab.com/group/project/component@1.0.0".
+func buildComponentReference(
+	repoURL string,
+	componentName string,
+	version string,
+	reference config.ReferenceConfig,
+) string {
+	if reference.Host != "" {
+		_, path, found := strings.Cut(repoURL, "/")
+		if found {
+			repoURL = reference.Host + "/" + path
+		} else {
+			repoURL = reference.Host
+		}
+	}
+
+	switch reference.Style {
+	case config.ReferenceStyleLatest:
+		version = "~latest"
+	case config.ReferenceStyleMajor:
+		version = majorVersion(version)
+	case config.ReferenceStyleSha:
+		if reference.Ref == "" {
+			log.WithField("style", reference.Style).Fatal("The usage reference sets no ref")
+		}
+
+		version = reference.Ref
+	}
+
+	return fmt.Sprintf("%s/%s@%s", repoURL, componentName, version)
+}
+
+// majorVersion returns the major part of a semantic version, keeping a "v" prefix. Versions
+// that are not semantic versions, like branch names, are returned unchanged.
+//
+// Parameters:
+//   - version: The version, e.g. "1.2.3" or "v1.2.3".
+//
+// Returns:
+//   - string: The major version, e.g. "1" or "v1".
+func majorVersion(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	number := strings.TrimPrefix(major, "v")
+
+	if number == "" || strings.Trim(number, "0123456789") != "" {
+		return version
+	}
+
+	return major
+}
+
+// buildUsageExample creates a YAML snippet that includes the component. The snippet lists
+// every mandatory input with a placeholder matching its type. If full is true, all optional
+// inputs are added as comments with their default values.
+//
+// Parameters:
+//   - component: The component to include. The inputs are expected to be sorted.
+//   - componentReference: The reference used in the `component` keyword of the include.
+//   - full: If true, optional inputs are added as comments.
+//
+// Returns:
+//   - string: The YAML snippet including the component.
+func buildUsageExample(component Component, componentReference string, full bool) string {
+	inputLines := []string{}
+
+	for _, input := range component.Inputs {
+		if isMandatoryInput(input) {
+			inputLines = append(inputLines, fmt.Sprintf("      %s: %s", input.Name, inputPlaceholder(input)))
+		} else if full {
+			inputLines = append(inputLines, fmt.Sprintf("      # %s: %s", input.Name, formatYamlValue(input.Default)))
+		}
+	}
+
+	usageExample := "include:\n" +
+		fmt.Sprintf("  - component: %q\n", componentReference)
+
+	if len(inputLines) == 0 {
+		return usageExample + "    inputs: {}\n"
+	}
+
+	return usageExample + "    inputs:\n" + strings.Join(inputLines, "\n") + "\n"
+}
+
+// isMandatoryInput checks if an input has to be set when including the component.
+//
+// Parameters:
+//   - input: The input to check.
+//
+// Returns:
+//   - bool: True if the input has no default value.
+func isMandatoryInput(input Input) bool {
+	return input.Default == nil
+}
+
+// hasOptionalInputs checks if a component has at least one input with a default value.
+//
+// Parameters:
+//   - component: The component to check.
+//
+// Returns:
+//   - bool: True if at least one input has a default value.
+func hasOptionalInputs(component Component) bool {
+	for _, input := range component.Inputs {
+		if !isMandatoryInput(input) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inputPlaceholder returns a placeholder value for a mandatory input, matching the input's type.
+// If the input has options, the first option is used.
+//
+// Parameters:
+//   - input: The input for which to create a placeholder.
+//
+// Returns:
+//   - string: The placeholder as YAML value.
+func inputPlaceholder(input Input) string {
+	if len(input.Options) > 0 {
+		return formatYamlValue(input.Options[0])
+	}
+
+	switch input.Type {
+	case "number":
+		return "0"
+	case "boolean":
+		return "false"
+	case "array":
+		return "[]"
+	default:
+		return fmt.Sprintf("%q", "<"+input.Name+">")
+	}
+}
+
+// formatYamlValue formats a value as a single line YAML value, with strings in double quotes.
+//
+// Parameters:
+//   - value: The value to format.
+//
+// Returns:
+//   - string: The formatted YAML value.
+func formatYamlValue(value interface{}) string {
+	node := &yaml.Node{}
+
+	err := node.Encode(value)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	setSingleLineStyle(node)
+
+	formattedValue, err := yaml.Marshal(node)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return strings.TrimSpace(string(formattedValue))
+}
+
+// setSingleLineStyle sets the flow style on all collections and the double-quoted style
+// on all strings of a YAML node.
+//
+// Parameters:
+//   - node: The YAML node to style.
+func setSingleLineStyle(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.SequenceNode, yaml.MappingNode:
+		node.Style = yaml.FlowStyle
+	case yaml.ScalarNode:
+		if node.Tag == "!!str" {
+			node.Style = yaml.DoubleQuotedStyle
+		}
+	case yaml.DocumentNode, yaml.AliasNode:
+	}
+
+	for _, child := range node.Content {
+		setSingleLineStyle(child)
+	}
+}
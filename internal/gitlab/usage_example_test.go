@@ -0,0 +1,144 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/erNail/labdoc/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildUsageExampleWithoutMandatoryInputsUsesEmptyInputs(t *testing.T) {
+	t.Parallel()
+
+	component := Component{
+		Name:   "component",
+		Inputs: []Input{{Name: "stage", Type: "string", Default: "test"}},
+	}
+
+	expectedUsageExample := "include:\n" +
+		"  - component: \"github.com/test/component@1.0.0\"\n" +
+		"    inputs: {}\n"
+
+	assert.Equal(t, expectedUsageExample, buildUsageExample(component, "github.com/test/component@1.0.0", false))
+}
+
+func TestBuildUsageExampleListsMandatoryInputsWithPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	component := Component{
+		Name: "component",
+		Inputs: []Input{
+			{Name: "array-input", Type: "array"},
+			{Name: "boolean-input", Type: "boolean"},
+			{Name: "number-input", Type: "number"},
+			{Name: "optional-input", Type: "string", Default: "docs"},
+			{Name: "options-input", Type: "string", Options: []interface{}{"one", "two"}},
+			{Name: "string-input", Type: "string"},
+			{Name: "untyped-input"},
+		},
+	}
+
+	expectedUsageExample := "include:\n" +
+		"  - component: \"github.com/test/component@1.0.0\"\n" +
+		"    inputs:\n" +
+		"      array-input: []\n" +
+		"      boolean-input: false\n" +
+		"      number-input: 0\n" +
+		"      options-input: \"one\"\n" +
+		"      string-input: \"<string-input>\"\n" +
+		"      untyped-input: \"<untyped-input>\"\n"
+
+	assert.Equal(t, expectedUsageExample, buildUsageExample(component, "github.com/test/component@1.0.0", false))
+}
+
+func TestBuildUsageExampleCommentsOutOptionalInputsInFullExample(t *testing.T) {
+	t.Parallel()
+
+	component := Component{
+		Name: "component",
+		Inputs: []Input{
+			{Name: "array-input", Type: "array", Default: []interface{}{"a", "b"}},
+			{Name: "boolean-input", Type: "boolean", Default: true},
+			{Name: "mandatory-input", Type: "string"},
+			{Name: "number-input", Type: "number", Default: 1},
+			{Name: "string-input", Type: "string", Default: ""},
+		},
+	}
+
+	expectedUsageExample := "include:\n" +
+		"  - component: \"github.com/test/component@1.0.0\"\n" +
+		"    inputs:\n" +
+		"      # array-input: [\"a\", \"b\"]\n" +
+		"      # boolean-input: true\n" +
+		"      mandatory-input: \"<mandatory-input>\"\n" +
+		"      # number-input: 1\n" +
+		"      # string-input: \"\"\n"
+
+	assert.Equal(t, expectedUsageExample, buildUsageExample(component, "github.com/test/component@1.0.0", true))
+}
+
+func TestHasOptionalInputsDetectsInputsWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, hasOptionalInputs(Component{Inputs: []Input{{Name: "mandatory"}}}))
+	assert.True(t, hasOptionalInputs(Component{Inputs: []Input{{Name: "optional", Default: false}}}))
+}
+
+func TestBuildComponentReferenceSupportsAllStyles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		reference config.ReferenceConfig
+		expected  string
+	}{
+		{config.ReferenceConfig{Style: config.ReferenceStylePinned}, "gitlab.com/group/project/component@1.2.3"},
+		{config.ReferenceConfig{Style: config.ReferenceStyleLatest}, "gitlab.com/group/project/component@~latest"},
+		{config.ReferenceConfig{Style: config.ReferenceStyleMajor}, "gitlab.com/group/project/component@1"},
+		{
+			config.ReferenceConfig{Style: config.ReferenceStyleSha, Ref: "0123abc"},
+			"gitlab.com/group/project/component@0123abc",
+		},
+		{
+			config.ReferenceConfig{Style: config.ReferenceStyleSha, Ref: "$CI_COMMIT_SHA"},
+			"gitlab.com/group/project/component@$CI_COMMIT_SHA",
+		},
+		{
+			config.ReferenceConfig{Style: config.ReferenceStylePinned, Host: "$CI_SERVER_FQDN"},
+			"$CI_SERVER_FQDN/group/project/component@1.2.3",
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual := buildComponentReference("gitlab.com/group/project", "component", "1.2.3", testCase.reference)
+		assert.Equal(t, testCase.expected, actual)
+	}
+}
+
+func TestMajorVersionKeepsPrefixAndNonSemanticVersions(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1", majorVersion("1.2.3"))
+	assert.Equal(t, "10", majorVersion("10"))
+	assert.Equal(t, "main", majorVersion("main"))
+	assert.Equal(t, "v1", majorVersion("v1.2.3"))
+	assert.Equal(t, "v", majorVersion("v"))
+	assert.Equal(t, "version.1", majorVersion("version.1"))
+}
+
+func TestBuildUsageVariantsCreatesVariantPerReference(t *testing.T) {
+	t.Parallel()
+
+	component := Component{Name: "component"}
+	references := []config.ReferenceConfig{
+		{Title: "Pinned", Style: config.ReferenceStylePinned},
+		{Title: "Latest", Style: config.ReferenceStyleLatest},
+	}
+
+	usageVariants := buildUsageVariants(component, "gitlab.com/group/project", "1.0.0", references)
+
+	assert.Len(t, usageVariants, 2)
+	assert.Equal(t, "Pinned", usageVariants[0].Title)
+	assert.Equal(t, "gitlab.com/group/project/component@1.0.0", usageVariants[0].Reference)
+	assert.Equal(t, "Latest", usageVariants[1].Title)
+	assert.Contains(t, usageVariants[1].UsageExample, `component: "gitlab.com/group/project/component@~latest"`)
+}
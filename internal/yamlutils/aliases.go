@@ -0,0 +1,207 @@
+package yamlutils
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// mergeKeyTag is the YAML tag of the `<<` merge key.
+	mergeKeyTag = "!!merge"
+	// maxResolvedNodes is the maximum number of nodes of a node with resolved aliases.
+	maxResolvedNodes = 100000
+)
+
+// ResolveAliases returns a copy of the node in which all aliases are replaced by a copy
+// of the anchored node and all merge keys (`<<`) are replaced by the merged keys.
+// Keys that are set explicitly take precedence over merged keys. If multiple mappings
+// are merged, earlier mappings take precedence over later ones. Anchors are removed from the copy.
+// The copy may have at most maxResolvedNodes nodes, so that chains of aliases that expand
+// exponentially, like the "billion laughs" attack, fail instead of exhausting the memory.
+//
+// Parameters:
+//   - node: The node to resolve.
+//
+// Returns:
+//   - *yaml.Node: A copy of the node without aliases and merge keys.
+//   - error: An error if the copy has more than maxResolvedNodes nodes.
+func ResolveAliases(node *yaml.Node) (*yaml.Node, error) {
+	resolver := &aliasResolver{}
+
+	resolvedNode := resolver.resolve(node)
+	if resolver.resolvedNodes > maxResolvedNodes {
+		return nil, fmt.Errorf("resolving YAML aliases exceeds the limit of %d nodes", maxResolvedNodes)
+	}
+
+	return resolvedNode, nil
+}
+
+// aliasResolver resolves aliases and merge keys while counting the created nodes.
+type aliasResolver struct {
+	resolvedNodes int
+}
+
+// resolve returns a copy of the node without aliases and merge keys. Once more than
+// maxResolvedNodes nodes are created, the remaining nodes are not resolved.
+//
+// Parameters:
+//   - node: The node to resolve.
+//
+// Returns:
+//   - *yaml.Node: A copy of the node without aliases and merge keys.
+func (resolver *aliasResolver) resolve(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	if node.Kind == yaml.AliasNode {
+		return resolver.resolve(node.Alias)
+	}
+
+	resolver.resolvedNodes++
+
+	resolvedNode := *node
+	resolvedNode.Anchor = ""
+	resolvedNode.Content = []*yaml.Node{}
+
+	if resolver.resolvedNodes > maxResolvedNodes {
+		return &resolvedNode
+	}
+
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			resolvedNode.Content = append(resolvedNode.Content, resolver.resolve(child))
+		}
+
+		return &resolvedNode
+	}
+
+	explicitKeys := map[string]bool{}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !isMergeKey(node.Content[i]) {
+			explicitKeys[node.Content[i].Value] = true
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !isMergeKey(node.Content[i]) {
+			resolvedNode.Content = append(
+				resolvedNode.Content,
+				resolver.resolve(node.Content[i]),
+				resolver.resolve(node.Content[i+1]),
+			)
+
+			continue
+		}
+
+		for _, mergedNode := range resolver.mergedMappings(node.Content[i+1]) {
+			for j := 0; j+1 < len(mergedNode.Content); j += 2 {
+				key := mergedNode.Content[j].Value
+				if explicitKeys[key] {
+					continue
+				}
+
+				explicitKeys[key] = true
+				resolvedNode.Content = append(resolvedNode.Content, mergedNode.Content[j], mergedNode.Content[j+1])
+			}
+		}
+	}
+
+	return &resolvedNode
+}
+
+// AliasedAnchors returns the names of all anchors that are used by at least one alias.
+//
+// Parameters:
+//   - node: The node to search for aliases.
+//
+// Returns:
+//   - map[string]bool: The names of all anchors that are used by an alias.
+func AliasedAnchors(node *yaml.Node) map[string]bool {
+	aliasedAnchors := map[string]bool{}
+	collectAliasedAnchors(node, aliasedAnchors)
+
+	return aliasedAnchors
+}
+
+// CopyNode creates a deep copy of a node.
+//
+// Parameters:
+//   - node: The node to copy.
+//
+// Returns:
+//   - *yaml.Node: The copy of the node.
+func CopyNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	nodeCopy := *node
+	nodeCopy.Content = make([]*yaml.Node, 0, len(node.Content))
+
+	for _, child := range node.Content {
+		nodeCopy.Content = append(nodeCopy.Content, CopyNode(child))
+	}
+
+	return &nodeCopy
+}
+
+// collectAliasedAnchors adds the names of all anchors used by aliases within the node to the map.
+//
+// Parameters:
+//   - node: The node to search for aliases.
+//   - aliasedAnchors: The map to which the anchor names are added.
+func collectAliasedAnchors(node *yaml.Node, aliasedAnchors map[string]bool) {
+	if node == nil {
+		return
+	}
+
+	if node.Kind == yaml.AliasNode {
+		aliasedAnchors[node.Value] = true
+
+		return
+	}
+
+	for _, child := range node.Content {
+		collectAliasedAnchors(child, aliasedAnchors)
+	}
+}
+
+// isMergeKey checks if a mapping key is the merge key `<<`.
+//
+// Parameters:
+//   - keyNode: The key node to check.
+//
+// Returns:
+//   - bool: True if the key is a merge key.
+func isMergeKey(keyNode *yaml.Node) bool {
+	return keyNode.Kind == yaml.ScalarNode && keyNode.Tag == mergeKeyTag
+}
+
+// mergedMappings returns the resolved mappings of the value of a merge key, which is either
+// a single mapping or a sequence of mappings.
+//
+// Parameters:
+//   - valueNode: The value of the merge key.
+//
+// Returns:
+//   - []*yaml.Node: The resolved mappings to merge, in order of precedence.
+func (resolver *aliasResolver) mergedMappings(valueNode *yaml.Node) []*yaml.Node {
+	resolvedValue := resolver.resolve(valueNode)
+
+	if resolvedValue.Kind == yaml.MappingNode {
+		return []*yaml.Node{resolvedValue}
+	}
+
+	mappings := []*yaml.Node{}
+
+	for _, item := range resolvedValue.Content {
+		if item.Kind == yaml.MappingNode {
+			mappings = append(mappings, item)
+		}
+	}
+
+	return mappings
+}
@@ -0,0 +1,100 @@
+package yamlutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestResolveAliasesReplacesAliasesAndMergeKeys(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `---
+.defaults: &defaults
+  stage: "build"
+  tags: ["docker"]
+.extra: &extra
+  stage: "test"
+  interruptible: true
+job:
+  <<: [*defaults, *extra]
+  tags: ["shell"]
+other: *defaults
+`
+
+	var node yaml.Node
+
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &node))
+
+	resolvedNode, err := ResolveAliases(node.Content[0])
+	require.NoError(t, err)
+
+	expectedYaml := `.defaults:
+  stage: "build"
+  tags: ["docker"]
+.extra:
+  stage: "test"
+  interruptible: true
+job:
+  stage: "build"
+  interruptible: true
+  tags: ["shell"]
+other:
+  stage: "build"
+  tags: ["docker"]
+`
+	assert.Equal(t, expectedYaml, FormatNodeAsYaml(*resolvedNode))
+}
+
+func TestResolveAliasesReturnsErrorIfAliasesExpandTooMuch(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `---
+a: &a ["lol", "lol", "lol", "lol", "lol", "lol", "lol", "lol", "lol"]
+b: &b [*a, *a, *a, *a, *a, *a, *a, *a, *a]
+c: &c [*b, *b, *b, *b, *b, *b, *b, *b, *b]
+d: &d [*c, *c, *c, *c, *c, *c, *c, *c, *c]
+e: &e [*d, *d, *d, *d, *d, *d, *d, *d, *d]
+f: &f [*e, *e, *e, *e, *e, *e, *e, *e, *e]
+g: &g [*f, *f, *f, *f, *f, *f, *f, *f, *f]
+h: &h [*g, *g, *g, *g, *g, *g, *g, *g, *g]
+i: &i [*h, *h, *h, *h, *h, *h, *h, *h, *h]
+`
+
+	var node yaml.Node
+
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &node))
+
+	_, err := ResolveAliases(node.Content[0])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the limit of 100000 nodes")
+}
+
+func TestAliasedAnchorsReturnsOnlyUsedAnchors(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `---
+first: &used "value"
+second: &unused "value"
+third: *used
+`
+
+	var node yaml.Node
+
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &node))
+	assert.Equal(t, map[string]bool{"used": true}, AliasedAnchors(&node))
+}
+
+func TestCopyNodeCreatesIndependentCopy(t *testing.T) {
+	t.Parallel()
+
+	var node yaml.Node
+
+	require.NoError(t, yaml.Unmarshal([]byte("key: \"value\"\n"), &node))
+
+	nodeCopy := CopyNode(node.Content[0])
+	nodeCopy.Content[1].Value = "changed"
+	assert.Equal(t, "value", node.Content[0].Content[1].Value)
+}
@@ -1,12 +1,14 @@
 package yamlutils
 
 import (
+	"bytes"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
 )
 
 // ReadYamlFilesFromDirectory reads all YAML files from the specified directory
@@ -72,3 +74,23 @@ func FormatCommentAsPlainText(comment string) string {
 
 	return cleanedComment
 }
+
+// FormatNodeAsYaml formats a YAML node as YAML string with an indentation of two spaces.
+//
+// Parameters:
+//   - node: The YAML node to format.
+//
+// Returns:
+//   - string: The formatted YAML.
+func FormatNodeAsYaml(node yaml.Node) string {
+	buffer := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2) //nolint:mnd
+
+	err := encoder.Encode(&node)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return buffer.String()
+}
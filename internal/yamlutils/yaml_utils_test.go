@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestReadYamlFilesReadsAllYamlFilesInTheCurrentWorkingDirectory(t *testing.T) {
@@ -161,3 +162,14 @@ func TestReadYamlFilesFromDirectoryWithNoYamlFilesReturnsNoYamlFiles(t *testing.
 	actualFileContentMap := ReadYamlFilesFromDirectory(filesystem, "empty_dir")
 	assert.Empty(t, actualFileContentMap)
 }
+
+func TestFormatNodeAsYamlUsesTwoSpaceIndentation(t *testing.T) {
+	t.Parallel()
+
+	var node yaml.Node
+
+	err := yaml.Unmarshal([]byte("rules:\n    - if: \"$CI\"\n"), &node)
+	require.NoError(t, err)
+
+	assert.Equal(t, "rules:\n  - if: \"$CI\"\n", FormatNodeAsYaml(*node.Content[0]))
+}